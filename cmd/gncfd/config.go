@@ -0,0 +1,390 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	failuredetector "github.com/sebastianopriscan/GNCFD/core/failure_detector"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	latencyfilter "github.com/sebastianopriscan/GNCFD/core/latency_filter"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+type SeedConfig struct {
+	Guid    string `json:"guid"`
+	Address string `json:"address"`
+}
+
+// SamplingConfig enables the Cyclon peer sampling, the seeds only bootstrap the partial view
+type SamplingConfig struct {
+	ViewSize        int    `json:"view_size"`
+	ShuffleLength   int    `json:"shuffle_length"`
+	ShuffleInterval string `json:"shuffle_interval"`
+}
+
+// LatencyFilterConfig filters the RTT samples, window and percentile apply to the
+// percentile and median kinds, alpha to the ewma one
+type LatencyFilterConfig struct {
+	Kind       string  `json:"kind"`
+	Window     int     `json:"window"`
+	Percentile float64 `json:"percentile"`
+	Alpha      float64 `json:"alpha"`
+}
+
+// FailureDetectorConfig tunes the phi accrual failure detector, the unset parameters
+// keep their defaults
+type FailureDetectorConfig struct {
+	PhiThreshold    float64 `json:"phi_threshold"`
+	Window          int     `json:"window"`
+	MinStdDeviation string  `json:"min_std_deviation"`
+}
+
+// SnapshotConfig restores the core from path at startup and saves it there at shutdown,
+// and every checkpoint_interval if set
+type SnapshotConfig struct {
+	Path               string `json:"path"`
+	CheckpointInterval string `json:"checkpoint_interval"`
+}
+
+type Config struct {
+	ListenAddress string `json:"listen_address"`
+	// Address the other nodes should use to reach this one, listen_address if empty
+	AdvertiseAddress string `json:"advertise_address"`
+	Transport        string `json:"transport"`
+
+	Guid    string       `json:"guid"`
+	Session string       `json:"session"`
+	Seeds   []SeedConfig `json:"seeds"`
+	// Address of a node to join through, session, space and dimension are then taken from it
+	Join string `json:"join"`
+
+	Space     string  `json:"space"`
+	Dimension int     `json:"dimension"`
+	Ce        float64 `json:"ce"`
+	Cc        float64 `json:"cc"`
+	// Rho of the gravity pulling the coordinates toward the centroid, calibrated on
+	// milliseconds as in Ledlie et al. (1024ms there), empty disables it
+	Gravity string `json:"gravity"`
+
+	LatencyFilter   *LatencyFilterConfig   `json:"latency_filter"`
+	FailureDetector *FailureDetectorConfig `json:"failure_detector"`
+
+	B              int    `json:"b"`
+	F              int    `json:"f"`
+	GossipInterval string `json:"gossip_interval"`
+	GossipJitter   string `json:"gossip_jitter"`
+	GossipMode     string `json:"gossip_mode"`
+	PeerSelection  string `json:"peer_selection"`
+
+	Sampling *SamplingConfig `json:"sampling"`
+	Snapshot *SnapshotConfig `json:"snapshot"`
+}
+
+type samplingConfig struct {
+	viewSize        int
+	shuffleLength   int
+	shuffleInterval time.Duration
+}
+
+type detectorConfig struct {
+	phiThreshold    float64
+	window          int
+	minStdDeviation time.Duration
+}
+
+type snapshotConfig struct {
+	path               string
+	checkpointInterval time.Duration
+}
+
+type seed struct {
+	guid    guid.Guid
+	address string
+}
+
+// nodeConfig is the validated form of Config
+type nodeConfig struct {
+	listenAddress    string
+	advertiseAddress string
+	transport        string
+
+	me      guid.Guid
+	session guid.Guid
+	seeds   []seed
+	join    string
+	// The guid was not configured, a snapshot may provide it
+	generatedGuid bool
+
+	space     string
+	dimension int
+	ce        float64
+	cc        float64
+	gravity   float64
+
+	latencyFilter latencyfilter.LatencyFilter
+	detector      detectorConfig
+
+	b              int
+	f              int
+	gossipInterval time.Duration
+	gossipJitter   time.Duration
+	gossipMode     gossip.GossipMode
+	peerSelection  string
+
+	sampling *samplingConfig
+	snapshot *snapshotConfig
+}
+
+var gossipModes = map[string]gossip.GossipMode{
+	"push":     gossip.PushMode,
+	"pull":     gossip.PullMode,
+	"exchange": gossip.ExchangeMode,
+}
+
+const (
+	percentileFilter = "percentile"
+	medianFilter     = "median"
+	ewmaFilter       = "ewma"
+)
+
+const (
+	randomSelection     = "random"
+	roundRobinSelection = "round-robin"
+	proximitySelection  = "proximity"
+)
+
+func loadConfig(path string) (*nodeConfig, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open config file, details: %s", err)
+	}
+	defer file.Close()
+
+	config := Config{
+		Transport:      "tcp",
+		Space:          nvs.EuclideanKind,
+		Ce:             0.25,
+		Cc:             0.25,
+		B:              3,
+		F:              2,
+		GossipInterval: "5s",
+		GossipJitter:   "0s",
+		GossipMode:     "push",
+		PeerSelection:  randomSelection,
+	}
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("unable to parse config file, details: %s", err)
+	}
+
+	return config.validate()
+}
+
+func (cfg *Config) validate() (*nodeConfig, error) {
+	retVal := &nodeConfig{
+		listenAddress:    cfg.ListenAddress,
+		advertiseAddress: cfg.AdvertiseAddress,
+		transport:        cfg.Transport,
+		space:            cfg.Space,
+		dimension:        cfg.Dimension,
+		ce:               cfg.Ce,
+		cc:               cfg.Cc,
+		b:                cfg.B,
+		f:                cfg.F,
+		peerSelection:    cfg.PeerSelection,
+		join:             cfg.Join,
+	}
+	if retVal.advertiseAddress == "" {
+		retVal.advertiseAddress = cfg.ListenAddress
+	}
+
+	if cfg.ListenAddress == "" {
+		return nil, errors.New("listen_address is mandatory")
+	}
+	if cfg.Join == "" && cfg.Dimension <= 0 {
+		return nil, errors.New("dimension should be greater than 0")
+	}
+	if cfg.Gravity != "" {
+		rho, err := time.ParseDuration(cfg.Gravity)
+		if err != nil || rho < 0 {
+			return nil, errors.New("gravity should be a non negative duration")
+		}
+		retVal.gravity = gravityRho(rho)
+	}
+	if cfg.B <= 0 || cfg.F <= 0 {
+		return nil, errors.New("b and f should be greater than 0")
+	}
+	if cfg.Space != nvs.EuclideanKind && cfg.Space != nvs.HeightVectorKind {
+		return nil, fmt.Errorf("unsupported space %s", cfg.Space)
+	}
+
+	var err error
+	if retVal.gossipInterval, err = time.ParseDuration(cfg.GossipInterval); err != nil || retVal.gossipInterval <= 0 {
+		return nil, errors.New("gossip_interval should be a positive duration")
+	}
+	if retVal.gossipJitter, err = time.ParseDuration(cfg.GossipJitter); err != nil || retVal.gossipJitter < 0 {
+		return nil, errors.New("gossip_jitter should be a non negative duration")
+	}
+	mode, ok := gossipModes[cfg.GossipMode]
+	if !ok {
+		return nil, fmt.Errorf("unknown gossip_mode %s", cfg.GossipMode)
+	}
+	retVal.gossipMode = mode
+
+	retVal.detector = detectorConfig{
+		phiThreshold: vivaldi.DefaultPhiThreshold,
+		window:       failuredetector.DefaultWindowSize,
+		//The peers of every round are drawn at random, so a healthy peer may be silent
+		//for a few rounds: the deviation should tolerate more than a missed interval
+		minStdDeviation: retVal.gossipInterval,
+	}
+	if cfg.FailureDetector != nil {
+		if err = cfg.FailureDetector.apply(&retVal.detector); err != nil {
+			return nil, fmt.Errorf("bad failure_detector, details: %s", err)
+		}
+	}
+
+	switch cfg.PeerSelection {
+	case randomSelection, roundRobinSelection, proximitySelection:
+	default:
+		return nil, fmt.Errorf("unknown peer_selection %s", cfg.PeerSelection)
+	}
+
+	if cfg.Sampling != nil {
+		if cfg.Sampling.ViewSize <= 0 || cfg.Sampling.ShuffleLength <= 0 || cfg.Sampling.ShuffleLength > cfg.Sampling.ViewSize {
+			return nil, errors.New("sampling view_size and shuffle_length should be positive, shuffle_length at most view_size")
+		}
+		interval, err := time.ParseDuration(cfg.Sampling.ShuffleInterval)
+		if err != nil || interval <= 0 {
+			return nil, errors.New("sampling shuffle_interval should be a positive duration")
+		}
+		retVal.sampling = &samplingConfig{
+			viewSize:        cfg.Sampling.ViewSize,
+			shuffleLength:   cfg.Sampling.ShuffleLength,
+			shuffleInterval: interval,
+		}
+	}
+
+	if cfg.LatencyFilter != nil {
+		if retVal.latencyFilter, err = cfg.LatencyFilter.build(); err != nil {
+			return nil, fmt.Errorf("bad latency_filter, details: %s", err)
+		}
+	}
+
+	if cfg.Snapshot != nil {
+		if cfg.Snapshot.Path == "" {
+			return nil, errors.New("snapshot path is mandatory")
+		}
+		retVal.snapshot = &snapshotConfig{path: cfg.Snapshot.Path}
+		if cfg.Snapshot.CheckpointInterval != "" {
+			interval, err := time.ParseDuration(cfg.Snapshot.CheckpointInterval)
+			if err != nil || interval <= 0 {
+				return nil, errors.New("snapshot checkpoint_interval should be a positive duration")
+			}
+			retVal.snapshot.checkpointInterval = interval
+		}
+	}
+
+	if cfg.Session == "" {
+		//The seed tells the session when joining
+		if cfg.Join == "" {
+			return nil, errors.New("session is mandatory")
+		}
+	} else if retVal.session, err = parseGuid(cfg.Session); err != nil {
+		return nil, fmt.Errorf("bad session guid, details: %s", err)
+	}
+
+	if cfg.Guid == "" {
+		if retVal.me, err = guid.GenerateGUID(); err != nil {
+			return nil, fmt.Errorf("unable to generate node guid, details: %s", err)
+		}
+		retVal.generatedGuid = true
+	} else if retVal.me, err = parseGuid(cfg.Guid); err != nil {
+		return nil, fmt.Errorf("bad node guid, details: %s", err)
+	}
+
+	for _, s := range cfg.Seeds {
+		seedGuid, err := parseGuid(s.Guid)
+		if err != nil {
+			return nil, fmt.Errorf("bad guid for seed %s, details: %s", s.Address, err)
+		}
+		if s.Address == "" {
+			return nil, fmt.Errorf("seed %s has no address", s.Guid)
+		}
+		retVal.seeds = append(retVal.seeds, seed{guid: seedGuid, address: s.Address})
+	}
+
+	return retVal, nil
+}
+
+// build fills the unset parameters with the defaults of the filter kind
+func (cfg *LatencyFilterConfig) build() (latencyfilter.LatencyFilter, error) {
+	window := cfg.Window
+	if window == 0 {
+		window = latencyfilter.DefaultWindowSize
+	}
+
+	switch cfg.Kind {
+	case percentileFilter:
+		percentile := cfg.Percentile
+		if percentile == 0 {
+			percentile = latencyfilter.DefaultPercentile
+		}
+		return latencyfilter.NewMovingPercentileFilter(window, percentile)
+	case medianFilter:
+		return latencyfilter.NewMedianFilter(window)
+	case ewmaFilter:
+		alpha := cfg.Alpha
+		if alpha == 0 {
+			alpha = latencyfilter.DefaultAlpha
+		}
+		return latencyfilter.NewEWMAFilter(alpha)
+	default:
+		return nil, fmt.Errorf("unknown kind %s", cfg.Kind)
+	}
+}
+
+// gravityRho converts a rho calibrated on RTTs in milliseconds for the core, which is
+// fed RTTs in nanoseconds: the pull (distance/rho)^2 is in the unit of the RTTs, so
+// rho scales with the square root of the unit to pull by the same amount
+func gravityRho(rho time.Duration) float64 {
+	return float64(rho) / float64(time.Millisecond) * math.Sqrt(float64(time.Millisecond))
+}
+
+// apply overrides the parameters of detector that are set
+func (cfg *FailureDetectorConfig) apply(detector *detectorConfig) error {
+	if cfg.PhiThreshold < 0 || cfg.Window < 0 {
+		return errors.New("phi_threshold and window should not be negative")
+	}
+	if cfg.PhiThreshold > 0 {
+		detector.phiThreshold = cfg.PhiThreshold
+	}
+	if cfg.Window > 0 {
+		detector.window = cfg.Window
+	}
+	if cfg.MinStdDeviation != "" {
+		deviation, err := time.ParseDuration(cfg.MinStdDeviation)
+		if err != nil || deviation <= 0 {
+			return errors.New("min_std_deviation should be a positive duration")
+		}
+		detector.minStdDeviation = deviation
+	}
+
+	return nil
+}
+
+// parseGuid accepts only the canonical 8-4-4-4-12 representation
+func parseGuid(str string) (guid.Guid, error) {
+	if len(str) != 36 {
+		return guid.Guid{}, errors.New("guid should be in the 8-4-4-4-12 format")
+	}
+	return guid.Deserialize([]byte(str))
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func TestFailureDetectorConfig(t *testing.T) {
+	config, err := loadConfig("gncfd.example.json")
+	if err != nil {
+		t.Fatalf("Unable to load example config, details: %s", err)
+	}
+
+	detector, err := newFailureDetector(config)
+	if err != nil {
+		t.Fatalf("Unable to create failure detector, details: %s", err)
+	}
+
+	peer, start := guid.Guid{1}, time.Now()
+	detector.Heartbeat(peer, start)
+
+	//A peer drawn once every few rounds is not suspected, a silent one eventually is
+	if phi, _ := detector.Phi(peer, start.Add(3*config.gossipInterval)); phi > config.detector.phiThreshold {
+		t.Fatalf("Peer suspected after 3 gossip intervals, phi %f", phi)
+	}
+	if phi, _ := detector.Phi(peer, start.Add(20*config.gossipInterval)); phi <= config.detector.phiThreshold {
+		t.Fatalf("Peer not suspected after 20 gossip intervals, phi %f", phi)
+	}
+
+	for _, bad := range []FailureDetectorConfig{
+		{PhiThreshold: -1},
+		{Window: -1},
+		{MinStdDeviation: "0s"},
+		{MinStdDeviation: "soon"},
+	} {
+		if err := bad.apply(&detectorConfig{}); err == nil {
+			t.Fatalf("Expected %+v to be refused", bad)
+		}
+	}
+}
+
+func TestGravityConfig(t *testing.T) {
+	config, err := loadConfig("gncfd.example.json")
+	if err != nil {
+		t.Fatalf("Unable to load example config, details: %s", err)
+	}
+	config.latencyFilter = nil
+
+	space, err := newSpace(config)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+	me, peer := guid.Guid{1}, guid.Guid{2}
+	nodeCore, err := vivaldi.NewVivaldiCore(me, make([]float64, space.Dimension()), space, config.ce, config.cc)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	if err = configureCore(nodeCore, config); err != nil {
+		t.Fatalf("Unable to configure core, details: %s", err)
+	}
+
+	//The peer is exactly 100ms away, so only gravity moves the coordinates
+	distance := float64(100 * time.Millisecond)
+	coords := make([]float64, space.Dimension())
+	coords[0] = distance
+	err = nodeCore.UpdateState(&vivaldi.VivaldiMetadata[float64]{
+		Data: map[guid.Guid]vivaldi.VivaldiMetaCoor[float64]{
+			peer: {Coords: coords},
+		},
+		Rtt: distance, Ej: 1., Communicator: peer,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	//The centroid is 50ms away: with rho 1024ms the pull is (50/1024)^2 ms
+	expected := math.Pow(50./1024., 2) * float64(time.Millisecond)
+	state, _ := nodeCore.GetMyState()
+	if moved := state.(*vivaldi.VivaldiPeerState[float64]).Coords[0]; math.Abs(moved-expected) > 1e-3 {
+		t.Fatalf("Gravity moved the coordinates by %f ns, %f expected", moved, expected)
+	}
+}
@@ -0,0 +1,324 @@
+// Command gncfd runs a full GNCFD node: a Vivaldi core exposed through the gRPC
+// gossip server and kept up to date by a blind counter gossiper
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/communication"
+	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
+	samplingendpoints "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/sampling/endpoints"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/endpoints"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/pb_go"
+	"github.com/sebastianopriscan/GNCFD/core"
+	failuredetector "github.com/sebastianopriscan/GNCFD/core/failure_detector"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	"github.com/sebastianopriscan/GNCFD/gossip/sampling"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+	lockedmap "github.com/sebastianopriscan/GNCFD/utils/locked_map"
+)
+
+const serverName = "gncfd"
+
+type node struct {
+	config *nodeConfig
+
+	core         *vivaldi.VivaldiCore[float64]
+	server       *connectionmanager.ServerInterface
+	gossipServer *endpoints.VivaldiGRPCGossipServer
+	peers        lockedmap.LockedMap[guid.Guid, communication.GNCFDCommunicationChannel]
+	gossiper     *gossip.BlindCounterGossiper
+	sampler      *sampling.Cyclon
+}
+
+func newSpace(config *nodeConfig) (*nvs.NormedVectorSpace[float64], error) {
+	if config.space == nvs.HeightVectorKind {
+		return nvs.NewHeightVectorSpace(config.dimension)
+	}
+	return nvs.NewRealEuclideanSpace(config.dimension)
+}
+
+// newFailureDetector expects a message from every peer about once per gossip round
+func newFailureDetector(config *nodeConfig) (*failuredetector.PhiAccrualDetector, error) {
+	return failuredetector.NewPhiAccrualDetector(config.detector.window, config.detector.minStdDeviation, config.gossipInterval)
+}
+
+// configureCore applies the tuning of the configuration to the core
+func configureCore(nodeCore *vivaldi.VivaldiCore[float64], config *nodeConfig) error {
+	nodeCore.SetLatencyFilter(config.latencyFilter)
+	if err := nodeCore.SetGravity(config.gravity); err != nil {
+		return err
+	}
+
+	detector, err := newFailureDetector(config)
+	if err != nil {
+		return fmt.Errorf("error creating failure detector, details: %s", err)
+	}
+	nodeCore.SetFailureDetector(detector)
+	return nodeCore.SetPhiThreshold(config.detector.phiThreshold)
+}
+
+func newPeerSelector(config *nodeConfig, nodeCore core.GNCFDCore) gossip.PeerSelector {
+	switch config.peerSelection {
+	case roundRobinSelection:
+		return gossip.NewRoundRobinSelector()
+	case proximitySelection:
+		return gossip.NewProximitySelector(nodeCore, 0.5, time.Now().UnixNano())
+	default:
+		return gossip.NewRandomSelector(time.Now().UnixNano())
+	}
+}
+
+func startNode(config *nodeConfig) (*node, error) {
+	nd := &node{
+		config: config,
+		peers:  lockedmap.LockedMap[guid.Guid, communication.GNCFDCommunicationChannel]{Map: make(map[guid.Guid]communication.GNCFDCommunicationChannel)},
+	}
+
+	var err error
+	nd.server, _, err = connectionmanager.GetServer(serverName, config.listenAddress, config.transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error activating server, details: %s", err)
+	}
+
+	var snapshot *vivaldi.VivaldiSnapshot[float64]
+	if config.snapshot != nil {
+		if snapshot, err = loadSnapshot(config); err != nil {
+			nd.stop()
+			return nil, fmt.Errorf("error loading snapshot, details: %s", err)
+		}
+	}
+
+	var incarnation uint64
+	if config.join != "" {
+		if incarnation, err = joinSession(config); err != nil {
+			nd.stop()
+			return nil, fmt.Errorf("error joining through %s, details: %s", config.join, err)
+		}
+	}
+
+	space, err := newSpace(config)
+	if err != nil {
+		nd.stop()
+		return nil, fmt.Errorf("error creating space, details: %s", err)
+	}
+
+	if snapshot != nil {
+		nd.core, err = vivaldi.RestoreVivaldiCore(snapshot, space, config.ce, config.cc)
+	} else {
+		nd.core, err = vivaldi.NewVivaldiCore(config.me, make([]float64, space.Dimension()), space, config.ce, config.cc)
+	}
+	if err != nil {
+		nd.stop()
+		return nil, fmt.Errorf("error creating core, details: %s", err)
+	}
+	nd.core.SetCoreSession(config.session)
+	nd.core.SetIncarnation(incarnation)
+	if err = configureCore(nd.core, config); err != nil {
+		nd.stop()
+		return nil, fmt.Errorf("error configuring core, details: %s", err)
+	}
+	if config.snapshot != nil && config.snapshot.checkpointInterval > 0 {
+		if err = nd.core.StartCheckpointing(config.snapshot.path, config.snapshot.checkpointInterval); err != nil {
+			nd.stop()
+			return nil, fmt.Errorf("error starting checkpoints, details: %s", err)
+		}
+	}
+
+	nd.gossiper = gossip.NewBlindCounterGossiper(&nd.peers, nd.core, config.b, config.f)
+	if err = nd.gossiper.SetGossipRound(config.gossipInterval, config.gossipJitter, config.gossipMode); err != nil {
+		nd.stop()
+		return nil, fmt.Errorf("error configuring gossip rounds, details: %s", err)
+	}
+	if err = nd.gossiper.SetPeerSelector(newPeerSelector(config, nd.core)); err != nil {
+		nd.stop()
+		return nil, fmt.Errorf("error configuring peer selection, details: %s", err)
+	}
+
+	coreMap := &lockedmap.LockedMap[guid.Guid, core.GNCFDCoreInteractionGate]{
+		Map: map[guid.Guid]core.GNCFDCoreInteractionGate{config.session: nd.core},
+	}
+	membership := &endpoints.MembershipSession{Core: nd.core, Gossiper: nd.gossiper}
+
+	//Every service shares the same listener, so they are all registered before starting
+	nd.gossipServer = endpoints.RegisterVivaldiGRPCServer(nd.server, coreMap)
+	if config.sampling != nil {
+		self := sampling.Descriptor{Guid: config.me, Address: config.advertiseAddress}
+		nd.sampler, err = sampling.NewCyclon(self, config.sampling.viewSize, config.sampling.shuffleLength,
+			samplingendpoints.NewSamplingRPCClient(config.session))
+		if err != nil {
+			nd.stop()
+			return nil, fmt.Errorf("error creating sampler, details: %s", err)
+		}
+		samplingendpoints.RegisterSamplingGRPCServer(nd.server, &lockedmap.LockedMap[guid.Guid, *sampling.Cyclon]{
+			Map: map[guid.Guid]*sampling.Cyclon{config.session: nd.sampler},
+		})
+
+		//Newcomers and leaving nodes go through the view, which feeds the gossiper
+		membership.OnJoin = func(peer guid.Guid, address string) {
+			nd.sampler.AddSeeds(sampling.Descriptor{Guid: peer, Address: address})
+		}
+		membership.OnLeave = nd.sampler.RemovePeer
+	}
+	endpoints.RegisterVivaldiMembershipServer(nd.server, &lockedmap.LockedMap[guid.Guid, *endpoints.MembershipSession]{
+		Map: map[guid.Guid]*endpoints.MembershipSession{config.session: membership},
+	})
+	nd.server.Start()
+
+	if nd.sampler != nil {
+		for _, s := range config.seeds {
+			nd.sampler.AddSeeds(sampling.Descriptor{Guid: s.guid, Address: s.address})
+		}
+		nd.sampler.FeedGossiper(nd.gossiper, dialGossipClient)
+	} else {
+		for _, s := range config.seeds {
+			client, err := endpoints.NewVivaldiRPCGossipClient(s.guid, s.address)
+			if err != nil {
+				nd.stop()
+				return nil, fmt.Errorf("error connecting to seed %s, details: %s", s.address, err)
+			}
+			nd.gossiper.AddPeer(s.guid, client)
+		}
+	}
+
+	nd.gossiper.ObserveSubject(nd.gossipServer)
+	nd.gossiper.StartGossiping()
+	if nd.sampler != nil {
+		nd.sampler.StartSampling(config.sampling.shuffleInterval)
+	}
+
+	return nd, nil
+}
+
+// loadSnapshot reads the snapshot of a previous run, nil if there is none. The
+// snapshot provides the guid and the session to join unless they are configured,
+// in which case they must match
+func loadSnapshot(config *nodeConfig) (*vivaldi.VivaldiSnapshot[float64], error) {
+	if _, err := os.Stat(config.snapshot.path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	snapshot, err := vivaldi.LoadSnapshot[float64](config.snapshot.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.generatedGuid {
+		config.me = snapshot.Me
+	} else if snapshot.Me != config.me {
+		return nil, fmt.Errorf("error: the snapshot belongs to node %v", snapshot.Me)
+	}
+	if config.join != "" && config.session == (guid.Guid{}) {
+		//The seed refuses the join if it does not host the session of the snapshot
+		config.session = snapshot.Session
+	} else if snapshot.Session != config.session {
+		return nil, fmt.Errorf("error: the snapshot belongs to session %v", snapshot.Session)
+	}
+
+	return snapshot, nil
+}
+
+// joinSession asks the join address for the session parameters, which replace the
+// configured ones, and for its peers, which are added to the seeds. It returns the
+// incarnation the node should start from
+func joinSession(config *nodeConfig) (uint64, error) {
+	session := ""
+	if config.session != (guid.Guid{}) {
+		session = config.session.String()
+	}
+	maxPeers := 0
+	if config.sampling != nil {
+		maxPeers = config.sampling.viewSize
+	}
+
+	info, err := endpoints.Join(config.join, session, config.me, config.advertiseAddress, maxPeers)
+	if err != nil {
+		return 0, err
+	}
+	if info.Support != pb_go.Support_REAL {
+		return 0, errors.New("error: only real cores are supported")
+	}
+	if info.SpaceKind != nvs.EuclideanKind && info.SpaceKind != nvs.HeightVectorKind {
+		return 0, fmt.Errorf("error: unsupported space %s", info.SpaceKind)
+	}
+
+	config.session = info.Session
+	config.space = info.SpaceKind
+	config.dimension = info.Dimension
+	config.seeds = append(config.seeds, seed{guid: info.Seed, address: config.join})
+	for _, peer := range info.Peers {
+		config.seeds = append(config.seeds, seed{guid: peer.Guid, address: peer.Address})
+	}
+
+	return info.Incarnation, nil
+}
+
+func dialGossipClient(peer sampling.Descriptor) (communication.GNCFDCommunicationChannel, error) {
+	return endpoints.NewVivaldiRPCGossipClient(peer.Guid, peer.Address)
+}
+
+func (nd *node) stop() {
+	if nd.sampler != nil {
+		nd.sampler.StopSampling()
+	}
+	if nd.gossiper != nil {
+		nd.gossiper.StopGossiping()
+
+		//Leaving on purpose spares the peers the failure detection
+		incarnation, _ := nd.core.GetIncarnation(nd.config.me)
+		if err := endpoints.Leave(nd.gossiper.PeerAddresses(), nd.config.session, nd.config.me, incarnation); err != nil {
+			log.Printf("error leaving the session, details: %s\n", err)
+		}
+
+		//Removing the peers releases their clients
+		for _, peer := range nd.gossiper.Peers() {
+			nd.gossiper.RemovePeer(peer)
+		}
+	}
+
+	if nd.core != nil && nd.config.snapshot != nil {
+		nd.core.StopCheckpointing()
+		if err := nd.core.SaveSnapshot(nd.config.snapshot.path); err != nil {
+			log.Printf("error saving snapshot, details: %s\n", err)
+		}
+	}
+
+	if err := connectionmanager.ReleaseServerUsage(nd.server); err != nil {
+		log.Printf("error deactivating server, details: %s\n", err)
+	}
+	if _, err := connectionmanager.DestroyServer(serverName); err != nil {
+		log.Printf("error destroying server, details: %s\n", err)
+	}
+}
+
+func main() {
+	configPath := flag.String("config", "gncfd.json", "path of the node configuration file")
+	flag.Parse()
+
+	config, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("error loading configuration, details: %s", err)
+	}
+
+	nd, err := startNode(config)
+	if err != nil {
+		log.Fatalf("error starting node, details: %s", err)
+	}
+	log.Printf("node %v listening on %s, session %v\n", config.me, config.listenAddress, config.session)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+
+	log.Println("shutting down")
+	nd.stop()
+}
@@ -11,3 +11,14 @@ type GNCFDCommunicationChannel interface {
 	Exchange(nodeCore core.GNCFDCoreInteractionGate, coreData core.CoreData, messageID guid.Guid) error
 	Forward(nodeCore core.GNCFDCoreInteractionGate, data core.CoreData) error
 }
+
+// ReleasableChannel is implemented by the channels holding resources, such as a
+// gRPC connection, that should be freed when the peer is dropped
+type ReleasableChannel interface {
+	Release() error
+}
+
+// AddressableChannel is implemented by the channels reaching their peer at a network address
+type AddressableChannel interface {
+	Address() string
+}
@@ -0,0 +1,316 @@
+package loopback
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	channelobserver "github.com/sebastianopriscan/GNCFD/utils/channel_observer"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// LinkConfig describes the behaviour of the link between two nodes
+type LinkConfig struct {
+	Rtt    time.Duration
+	Jitter time.Duration
+	Loss   float64
+}
+
+type link struct {
+	from guid.Guid
+	to   guid.Guid
+}
+
+// Node is the in-process counterpart of a gRPC server: gossipers observe it to
+// receive the messages to forward, exactly as they do with VivaldiGRPCGossipServer
+type Node struct {
+	channelobserver.ChannelObserverSubjectImpl
+
+	id   guid.Guid
+	core core.GNCFDCoreInteractionGate
+}
+
+func (nd *Node) GetGuid() guid.Guid {
+	return nd.id
+}
+
+// Network connects cores living in the same process, injecting latency,
+// losses and partitions on the links between them
+type Network struct {
+	mu sync.RWMutex
+
+	nodes       map[guid.Guid]*Node
+	links       map[link]LinkConfig
+	defaultLink LinkConfig
+	partitions  map[guid.Guid]int
+
+	realDelay bool
+
+	rand_mu sync.Mutex
+	rand    *rand.Rand
+}
+
+func NewNetwork(defaultLink LinkConfig, seed int64) *Network {
+	return &Network{
+		nodes:       make(map[guid.Guid]*Node),
+		links:       make(map[link]LinkConfig),
+		defaultLink: defaultLink,
+		partitions:  make(map[guid.Guid]int),
+		rand:        rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (nw *Network) AddNode(id guid.Guid, nodeCore core.GNCFDCoreInteractionGate) (*Node, error) {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	if _, present := nw.nodes[id]; present {
+		return nil, errors.New("error: a node with the same guid is already part of the network")
+	}
+
+	node := &Node{
+		ChannelObserverSubjectImpl: channelobserver.NewChannelObserverSubjectImpl(),
+		id:                         id,
+		core:                       nodeCore,
+	}
+	nw.nodes[id] = node
+
+	return node, nil
+}
+
+func (nw *Network) RemoveNode(id guid.Guid) {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	delete(nw.nodes, id)
+	delete(nw.partitions, id)
+}
+
+// SetLink configures the link in both directions
+func (nw *Network) SetLink(first guid.Guid, second guid.Guid, config LinkConfig) {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	nw.links[link{from: first, to: second}] = config
+	nw.links[link{from: second, to: first}] = config
+}
+
+// SetPartition assigns every given node to the same partition, nodes in
+// different partitions cannot communicate. Every node starts in partition 0
+func (nw *Network) SetPartition(partition int, nodes ...guid.Guid) {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	for _, node := range nodes {
+		nw.partitions[node] = partition
+	}
+}
+
+// HealPartitions brings every node back to partition 0
+func (nw *Network) HealPartitions() {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	nw.partitions = make(map[guid.Guid]int)
+}
+
+// SetRealDelay makes every call sleep for the round trip time of its link,
+// by default the round trip time is only reported to the cores
+func (nw *Network) SetRealDelay(realDelay bool) {
+	nw.mu.Lock()
+	defer nw.mu.Unlock()
+
+	nw.realDelay = realDelay
+}
+
+// NewChannel returns the channel the node from uses to communicate with the node to
+func (nw *Network) NewChannel(from guid.Guid, to guid.Guid) *LoopbackChannel {
+	return &LoopbackChannel{network: nw, from: from, to: to}
+}
+
+// traverse returns the receiving node and the rtt measured on the link,
+// failing if the link is partitioned or the message is lost
+func (nw *Network) traverse(from guid.Guid, to guid.Guid) (*Node, float64, error) {
+	nw.mu.RLock()
+	node, present := nw.nodes[to]
+	config, configured := nw.links[link{from: from, to: to}]
+	if !configured {
+		config = nw.defaultLink
+	}
+	partitioned := nw.partitions[from] != nw.partitions[to]
+	realDelay := nw.realDelay
+	nw.mu.RUnlock()
+
+	if !present {
+		return nil, 0., fmt.Errorf("error: node %v unreachable", to)
+	}
+	if partitioned {
+		return nil, 0., fmt.Errorf("error: node %v unreachable, network partitioned", to)
+	}
+
+	nw.rand_mu.Lock()
+	lost := nw.rand.Float64() < config.Loss
+	jitter := time.Duration(0)
+	if config.Jitter > 0 {
+		jitter = time.Duration(nw.rand.Int63n(int64(config.Jitter)))
+	}
+	nw.rand_mu.Unlock()
+
+	if lost {
+		return nil, 0., fmt.Errorf("error: message to node %v lost", to)
+	}
+
+	rtt := config.Rtt + jitter
+	if realDelay {
+		time.Sleep(rtt)
+	}
+
+	return node, float64(rtt.Nanoseconds()), nil
+}
+
+// envelope is the payload handed to the gossipers, it keeps the message id
+// along with the data as NodeUpdates does for the gRPC channel
+type envelope struct {
+	messageID guid.Guid
+	data      core.CoreData
+}
+
+// LoopbackChannel implements communication.GNCFDCommunicationChannel between two nodes of a Network
+type LoopbackChannel struct {
+	network *Network
+	from    guid.Guid
+	to      guid.Guid
+}
+
+func (lc *LoopbackChannel) Push(nodeCore core.GNCFDCoreInteractionGate, coreData core.CoreData, messageID guid.Guid) error {
+	return lc.deliver(coreData, messageID)
+}
+
+func (lc *LoopbackChannel) deliver(coreData core.CoreData, messageID guid.Guid) error {
+	receiver, rtt, err := lc.network.traverse(lc.from, lc.to)
+	if err != nil {
+		return fmt.Errorf("unable to push state updates, details: %s", err)
+	}
+
+	payload, err := withRtt(coreData, rtt)
+	if err != nil {
+		return fmt.Errorf("error in parameters preparation, details: %s", err)
+	}
+	//The receiver core keeps the payload, the forwarders get their own copy
+	forwardable, err := withRtt(coreData, rtt)
+	if err != nil {
+		return fmt.Errorf("error in parameters preparation, details: %s", err)
+	}
+
+	receiver.PushToChannels(&gossip.MessageToForward{MessageID: messageID, Sender: lc.from,
+		Payload: &envelope{messageID: messageID, data: forwardable}})
+
+	if err = receiver.core.UpdateState(payload); err != nil {
+		return fmt.Errorf("error in core update, push failed, details: %s", err)
+	}
+
+	return nil
+}
+
+func (lc *LoopbackChannel) Pull(nodeCore core.GNCFDCoreInteractionGate) error {
+	receiver, rtt, err := lc.network.traverse(lc.from, lc.to)
+	if err != nil {
+		return fmt.Errorf("error in pull invocation, details: %s", err)
+	}
+
+	return pullFrom(nodeCore, receiver, rtt)
+}
+
+func (lc *LoopbackChannel) Exchange(nodeCore core.GNCFDCoreInteractionGate, coreData core.CoreData, messageID guid.Guid) error {
+	receiver, rtt, err := lc.network.traverse(lc.from, lc.to)
+	if err != nil {
+		return fmt.Errorf("unable to exchange state updates, details: %s", err)
+	}
+
+	payload, err := withRtt(coreData, rtt)
+	if err != nil {
+		return fmt.Errorf("error in parameters preparation, details: %s", err)
+	}
+	//The receiver core keeps the payload, the forwarders get their own copy
+	forwardable, err := withRtt(coreData, rtt)
+	if err != nil {
+		return fmt.Errorf("error in parameters preparation, details: %s", err)
+	}
+
+	receiver.PushToChannels(&gossip.MessageToForward{MessageID: messageID, Sender: lc.from,
+		Payload: &envelope{messageID: messageID, data: forwardable}})
+
+	if err = receiver.core.UpdateState(payload); err != nil {
+		return fmt.Errorf("error: unable to push gossip, exchange failed, details: %s", err)
+	}
+
+	return pullFrom(nodeCore, receiver, rtt)
+}
+
+func (lc *LoopbackChannel) Forward(nodeCore core.GNCFDCoreInteractionGate, data core.CoreData) error {
+	myState, err := nodeCore.GetMyState()
+	if err != nil {
+		return fmt.Errorf("error: got bad state from core, details: %s", err)
+	}
+
+	message, ok := data.(*envelope)
+	if !ok {
+		return errors.New("error: bad message passed")
+	}
+
+	var forwarded core.CoreData
+	switch nodes := message.data.(type) {
+	case *vivaldi.VivaldiMetadata[float64]:
+		state, ok := myState.(*vivaldi.VivaldiPeerState[float64])
+		if !ok {
+			return errors.New("error: bad message passed (incompatible support)")
+		}
+		forwarded = vivaldi.ForwardedMetadata(nodes, state)
+	case *vivaldi.VivaldiMetadata[complex128]:
+		state, ok := myState.(*vivaldi.VivaldiPeerState[complex128])
+		if !ok {
+			return errors.New("error: bad message passed (incompatible support)")
+		}
+		forwarded = vivaldi.ForwardedMetadata(nodes, state)
+	default:
+		return errors.New("error: bad message passed")
+	}
+
+	return lc.deliver(forwarded, message.messageID)
+}
+
+func pullFrom(nodeCore core.GNCFDCoreInteractionGate, remote *Node, rtt float64) error {
+	updates, err := remote.core.GetStateUpdates()
+	if err != nil {
+		return errors.New("error in getting core updates, pull failed")
+	}
+
+	payload, err := withRtt(updates, rtt)
+	if err != nil {
+		return fmt.Errorf("error in data translation, details: %s", err)
+	}
+
+	if err = nodeCore.UpdateState(payload); err != nil {
+		return fmt.Errorf("error in state update, details: %s", err)
+	}
+
+	return nil
+}
+
+// withRtt deep copies the metadata, as gRPC serialization would, so that cores
+// never share coordinate slices, and stamps it with the measured rtt
+func withRtt(data core.CoreData, rtt float64) (core.CoreData, error) {
+	switch nodes := data.(type) {
+	case *vivaldi.VivaldiMetadata[float64]:
+		return vivaldi.CloneMetadata(nodes, rtt), nil
+	case *vivaldi.VivaldiMetadata[complex128]:
+		return vivaldi.CloneMetadata(nodes, rtt), nil
+	default:
+		return nil, errors.New("wrong metadata format")
+	}
+}
@@ -0,0 +1,118 @@
+package loopback
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func TestVivaldiConvergence(t *testing.T) {
+	const nodes = 100
+	const rounds = 150
+
+	space, err := nvs.NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	random := rand.New(rand.NewSource(42))
+	network := NewNetwork(LinkConfig{}, 42)
+
+	guids := make([]guid.Guid, nodes)
+	positions := make([][2]float64, nodes)
+	cores := make([]*vivaldi.VivaldiCore[float64], nodes)
+
+	for i := 0; i < nodes; i++ {
+		guids[i] = guid.Guid{byte(i), byte(i >> 8), 1}
+		positions[i] = [2]float64{random.Float64() * 100., random.Float64() * 100.}
+
+		cores[i], err = vivaldi.NewVivaldiCore(guids[i], []float64{0., 0.}, space, 0.25, 0.25)
+		if err != nil {
+			t.Fatalf("Unable to create core, details: %s", err)
+		}
+		if _, err = network.AddNode(guids[i], cores[i]); err != nil {
+			t.Fatalf("Unable to add node, details: %s", err)
+		}
+	}
+
+	trueRtt := func(i, j int) float64 {
+		return math.Hypot(positions[i][0]-positions[j][0], positions[i][1]-positions[j][1]) + 1.
+	}
+
+	for i := 0; i < nodes; i++ {
+		for j := i + 1; j < nodes; j++ {
+			network.SetLink(guids[i], guids[j], LinkConfig{Rtt: time.Duration(trueRtt(i, j) * float64(time.Millisecond))})
+		}
+	}
+
+	for round := 0; round < rounds; round++ {
+		for i := 0; i < nodes; i++ {
+			j := random.Intn(nodes - 1)
+			if j >= i {
+				j++
+			}
+
+			updates, err := cores[i].GetStateUpdates()
+			if err != nil {
+				t.Fatalf("Unable to get updates, details: %s", err)
+			}
+			if err = network.NewChannel(guids[i], guids[j]).Exchange(cores[i], updates, guid.Guid{}); err != nil {
+				t.Fatalf("Unable to exchange, details: %s", err)
+			}
+		}
+	}
+
+	errors := make([]float64, 0, nodes*(nodes-1)/2)
+	for i := 0; i < nodes; i++ {
+		first, _ := cores[i].GetMyState()
+		firstPt, _ := nvs.NewPoint(space, first.(*vivaldi.VivaldiPeerState[float64]).Coords)
+		for j := i + 1; j < nodes; j++ {
+			second, _ := cores[j].GetMyState()
+			secondPt, _ := nvs.NewPoint(space, second.(*vivaldi.VivaldiPeerState[float64]).Coords)
+
+			predicted, _ := space.Distance(firstPt, secondPt)
+			actual := trueRtt(i, j) * float64(time.Millisecond)
+			errors = append(errors, math.Abs(predicted-actual)/actual)
+		}
+	}
+	sort.Float64s(errors)
+
+	if median := errors[len(errors)/2]; median > 0.2 {
+		t.Fatalf("Coordinates did not converge, median relative error %v", median)
+	}
+}
+
+func TestPartitionAndLoss(t *testing.T) {
+	space, _ := nvs.NewRealEuclideanSpace(2)
+	first, second := guid.Guid{1}, guid.Guid{2}
+
+	firstCore, _ := vivaldi.NewVivaldiCore(first, []float64{0., 0.}, space, 0.25, 0.25)
+	secondCore, _ := vivaldi.NewVivaldiCore(second, []float64{0., 0.}, space, 0.25, 0.25)
+
+	network := NewNetwork(LinkConfig{Rtt: time.Millisecond}, 1)
+	network.AddNode(first, firstCore)
+	network.AddNode(second, secondCore)
+
+	channel := network.NewChannel(first, second)
+
+	network.SetPartition(1, second)
+	if err := channel.Pull(firstCore); err == nil {
+		t.Fatalf("Pull across a partition should fail")
+	}
+
+	network.HealPartitions()
+	if err := channel.Pull(firstCore); err != nil {
+		t.Fatalf("Pull should succeed once healed, details: %s", err)
+	}
+
+	network.SetLink(first, second, LinkConfig{Rtt: time.Millisecond, Loss: 1.})
+	if err := channel.Pull(firstCore); err == nil {
+		t.Fatalf("Pull on a lossy link should fail")
+	}
+}
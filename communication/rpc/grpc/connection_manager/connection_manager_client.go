@@ -37,6 +37,7 @@ func NewGrpcCommunicationChannel(peer guid.Guid, address string) (*GrpcCommunica
 	if connection, ok := openCommunications[peer]; ok {
 		conn = connection.conn
 		connection.count++
+		openCommunications[peer] = connection
 	} else {
 
 		insecure := grpc.WithTransportCredentials(insecure.NewCredentials())
@@ -73,8 +74,10 @@ func InvalidateGrpcCommunicationChannel(chann *GrpcCommunicationChannel) error {
 
 	if cnct.count == 1 {
 		delete(openCommunications, chann.peer)
+		cnct.conn.Close()
 	} else {
 		cnct.count--
+		openCommunications[chann.peer] = cnct
 	}
 
 	chann.set = false
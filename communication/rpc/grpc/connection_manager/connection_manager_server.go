@@ -27,7 +27,11 @@ type ServerInterface struct {
 	set     bool
 }
 
+// Start serves the registered services, only the first user of a server owns its listener
 func (srv *ServerInterface) Start() {
+	if srv.Conn == nil {
+		return
+	}
 	go srv.Server.Serve(srv.Conn)
 }
 
@@ -38,11 +42,11 @@ func GetServer(name string, addr string, transport string, opts []grpc.ServerOpt
 	var server *grpc.Server
 
 	server_mu.Lock()
-	var ok bool
-
-	if entry, ok := availableInterfaces[name]; ok {
+	entry, ok := availableInterfaces[name]
+	if ok {
 		server = entry.server
 		entry.count++
+		availableInterfaces[name] = entry
 	} else {
 
 		lis, err := net.Listen(transport, addr)
@@ -78,6 +82,7 @@ func ReleaseServerUsage(interf *ServerInterface) error {
 	intCt := availableInterfaces[interf.name]
 
 	intCt.count--
+	availableInterfaces[interf.name] = intCt
 
 	interf.set = false
 
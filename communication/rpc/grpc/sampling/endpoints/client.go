@@ -0,0 +1,43 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/sampling/pb_go"
+	"github.com/sebastianopriscan/GNCFD/gossip/sampling"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// SamplingRPCClient carries the shuffles of a session, connecting to each target on demand
+type SamplingRPCClient struct {
+	session guid.Guid
+}
+
+func NewSamplingRPCClient(session guid.Guid) *SamplingRPCClient {
+	return &SamplingRPCClient{session: session}
+}
+
+func (sc *SamplingRPCClient) Shuffle(target sampling.Descriptor, sent []sampling.Descriptor) ([]sampling.Descriptor, error) {
+
+	conn, err := connectionmanager.NewGrpcCommunicationChannel(target.Guid, target.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error in obtaining connection for client, details: %s", err)
+	}
+	defer connectionmanager.InvalidateGrpcCommunicationChannel(conn)
+
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reply, err := pb_go.NewPeerSamplingClient(conn.Conn).Shuffle(timeout, &pb_go.ShuffleRequest{
+		Session: sc.session.String(),
+		Entries: asPbDescriptors(sent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error in shuffle invocation, details: %s", err)
+	}
+
+	return asDescriptors(reply.Entries)
+}
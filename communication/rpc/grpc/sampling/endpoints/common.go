@@ -0,0 +1,29 @@
+package endpoints
+
+import (
+	"fmt"
+
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/sampling/pb_go"
+	"github.com/sebastianopriscan/GNCFD/gossip/sampling"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func asPbDescriptors(descriptors []sampling.Descriptor) []*pb_go.Descriptor {
+	retVal := make([]*pb_go.Descriptor, len(descriptors))
+	for i, desc := range descriptors {
+		retVal[i] = &pb_go.Descriptor{Guid: desc.Guid.String(), Address: desc.Address, Age: uint32(desc.Age)}
+	}
+	return retVal
+}
+
+func asDescriptors(descriptors []*pb_go.Descriptor) ([]sampling.Descriptor, error) {
+	retVal := make([]sampling.Descriptor, len(descriptors))
+	for i, desc := range descriptors {
+		peer, err := guid.Deserialize([]byte(desc.Guid))
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing descriptor guid, details: %s", err)
+		}
+		retVal[i] = sampling.Descriptor{Guid: peer, Address: desc.Address, Age: int(desc.Age)}
+	}
+	return retVal, nil
+}
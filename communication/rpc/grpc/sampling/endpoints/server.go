@@ -0,0 +1,72 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/sampling/pb_go"
+	"github.com/sebastianopriscan/GNCFD/gossip/sampling"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+	lockedmap "github.com/sebastianopriscan/GNCFD/utils/locked_map"
+	"google.golang.org/grpc"
+)
+
+type SamplingGRPCServer struct {
+	pb_go.UnimplementedPeerSamplingServer
+	samplers *lockedmap.LockedMap[guid.Guid, *sampling.Cyclon]
+}
+
+type SamplingGRPCServerDesc struct {
+	Server   *connectionmanager.ServerInterface
+	Exists   bool
+	Sampling *SamplingGRPCServer
+}
+
+// RegisterSamplingGRPCServer adds the sampling service to serv, which must not be started yet
+func RegisterSamplingGRPCServer(serv *connectionmanager.ServerInterface, samplers *lockedmap.LockedMap[guid.Guid, *sampling.Cyclon]) *SamplingGRPCServer {
+	samplServ := &SamplingGRPCServer{samplers: samplers}
+	pb_go.RegisterPeerSamplingServer(serv.Server, samplServ)
+	return samplServ
+}
+
+func ActivateSamplingGRPCServer(name string, addr string, transport string,
+	opts []grpc.ServerOption, samplers *lockedmap.LockedMap[guid.Guid, *sampling.Cyclon]) (*SamplingGRPCServerDesc, error) {
+
+	serv, exist, err := connectionmanager.GetServer(name, addr, transport, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving server, details: %s", err)
+	}
+
+	samplServ := RegisterSamplingGRPCServer(serv, samplers)
+	serv.Start()
+
+	return &SamplingGRPCServerDesc{Server: serv, Exists: exist, Sampling: samplServ}, nil
+}
+
+func DeactivateSamplingGRPCServer(servDesc *SamplingGRPCServerDesc) error {
+	return connectionmanager.ReleaseServerUsage(servDesc.Server)
+}
+
+func (sgs *SamplingGRPCServer) Shuffle(ctx context.Context, request *pb_go.ShuffleRequest) (*pb_go.ShuffleReply, error) {
+
+	sessGuid, err := guid.Deserialize([]byte(request.Session))
+	if err != nil {
+		return nil, errors.New("error converting guid, shuffle failed")
+	}
+
+	sgs.samplers.Mu.RLock()
+	sampler, ok := sgs.samplers.Map[sessGuid]
+	sgs.samplers.Mu.RUnlock()
+	if !ok {
+		return nil, errors.New("error: no sampler with such session, shuffle failed")
+	}
+
+	received, err := asDescriptors(request.Entries)
+	if err != nil {
+		return nil, fmt.Errorf("error in data conversion, shuffle failed, details: %s", err)
+	}
+
+	return &pb_go.ShuffleReply{Entries: asPbDescriptors(sampler.HandleShuffle(received))}, nil
+}
@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: sampling.proto
+
+package pb_go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PeerSampling_Shuffle_FullMethodName = "/PeerSampling/Shuffle"
+)
+
+// PeerSamplingClient is the client API for PeerSampling service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PeerSamplingClient interface {
+	Shuffle(ctx context.Context, in *ShuffleRequest, opts ...grpc.CallOption) (*ShuffleReply, error)
+}
+
+type peerSamplingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPeerSamplingClient(cc grpc.ClientConnInterface) PeerSamplingClient {
+	return &peerSamplingClient{cc}
+}
+
+func (c *peerSamplingClient) Shuffle(ctx context.Context, in *ShuffleRequest, opts ...grpc.CallOption) (*ShuffleReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShuffleReply)
+	err := c.cc.Invoke(ctx, PeerSampling_Shuffle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PeerSamplingServer is the server API for PeerSampling service.
+// All implementations must embed UnimplementedPeerSamplingServer
+// for forward compatibility.
+type PeerSamplingServer interface {
+	Shuffle(context.Context, *ShuffleRequest) (*ShuffleReply, error)
+	mustEmbedUnimplementedPeerSamplingServer()
+}
+
+// UnimplementedPeerSamplingServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPeerSamplingServer struct{}
+
+func (UnimplementedPeerSamplingServer) Shuffle(context.Context, *ShuffleRequest) (*ShuffleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shuffle not implemented")
+}
+func (UnimplementedPeerSamplingServer) mustEmbedUnimplementedPeerSamplingServer() {}
+func (UnimplementedPeerSamplingServer) testEmbeddedByValue()                      {}
+
+// UnsafePeerSamplingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PeerSamplingServer will
+// result in compilation errors.
+type UnsafePeerSamplingServer interface {
+	mustEmbedUnimplementedPeerSamplingServer()
+}
+
+func RegisterPeerSamplingServer(s grpc.ServiceRegistrar, srv PeerSamplingServer) {
+	// If the following call pancis, it indicates UnimplementedPeerSamplingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PeerSampling_ServiceDesc, srv)
+}
+
+func _PeerSampling_Shuffle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShuffleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeerSamplingServer).Shuffle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeerSampling_Shuffle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerSamplingServer).Shuffle(ctx, req.(*ShuffleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PeerSampling_ServiceDesc is the grpc.ServiceDesc for PeerSampling service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PeerSampling_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "PeerSampling",
+	HandlerType: (*PeerSamplingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Shuffle",
+			Handler:    _PeerSampling_Shuffle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "sampling.proto",
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
+	"sync"
 	"time"
 
 	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
@@ -13,11 +13,19 @@ import (
 	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
 	"github.com/sebastianopriscan/GNCFD/utils/guid"
 	"github.com/sebastianopriscan/GNCFD/utils/ntptime"
+	"google.golang.org/protobuf/proto"
 )
 
+// Forwards reuse an RTT measured this recently instead of pinging the peer again
+const rttReuseInterval = 10 * time.Second
+
 type VivaldiRPCGossipClient struct {
 	client pb_go.GossipStatusClient
 	conn   *connectionmanager.GrpcCommunicationChannel
+
+	rtt_mu    sync.Mutex
+	lastRtt   float64
+	lastRttAt time.Time
 }
 
 func NewVivaldiRPCGossipClient(peer guid.Guid, address string) (*VivaldiRPCGossipClient, error) {
@@ -45,6 +53,43 @@ func (vgc *VivaldiRPCGossipClient) Release() error {
 	return nil
 }
 
+func (vgc *VivaldiRPCGossipClient) Address() string {
+	return vgc.conn.Address
+}
+
+// measureRtt pings the peer and returns the round trip time in nanoseconds,
+// measured on the local monotonic clock so that clock skew between hosts does not matter
+func (vgc *VivaldiRPCGossipClient) measureRtt() (float64, error) {
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := vgc.client.Ping(timeout, &pb_go.PingRequest{Nonce: start.UnixNano()})
+	if err != nil {
+		return 0., fmt.Errorf("unable to ping peer, details: %s", err)
+	}
+
+	rtt := float64(time.Since(start).Nanoseconds())
+
+	vgc.rtt_mu.Lock()
+	vgc.lastRtt, vgc.lastRttAt = rtt, time.Now()
+	vgc.rtt_mu.Unlock()
+
+	return rtt, nil
+}
+
+// recentRtt returns the last RTT measured toward the peer, pinging it only if that is too old
+func (vgc *VivaldiRPCGossipClient) recentRtt() (float64, error) {
+	vgc.rtt_mu.Lock()
+	rtt, at := vgc.lastRtt, vgc.lastRttAt
+	vgc.rtt_mu.Unlock()
+
+	if !at.IsZero() && time.Since(at) < rttReuseInterval {
+		return rtt, nil
+	}
+	return vgc.measureRtt()
+}
+
 func preparePush(nodeCore core.GNCFDCoreInteractionGate, updates core.CoreData) (*pb_go.NodeUpdates, error) {
 
 	if nodeCore.GetKind() != core_code {
@@ -72,7 +117,7 @@ func preparePush(nodeCore core.GNCFDCoreInteractionGate, updates core.CoreData)
 	return &pointsToSend, nil
 }
 
-func executePull(nodeCore core.GNCFDCoreInteractionGate, nodeUpdates *pb_go.NodeUpdates, time int64) error {
+func executePull(nodeCore core.GNCFDCoreInteractionGate, nodeUpdates *pb_go.NodeUpdates, rtt float64) error {
 
 	sessGuid, err := guid.Deserialize([]byte(nodeUpdates.CoreSession))
 	if err != nil {
@@ -89,10 +134,10 @@ func executePull(nodeCore core.GNCFDCoreInteractionGate, nodeUpdates *pb_go.Node
 		if err != nil {
 			return fmt.Errorf("error in data translation, details: %s", err)
 		}
-		meta := vivaldi.VivaldiMetadata[float64]{
+		meta := &vivaldi.VivaldiMetadata[float64]{
 			Session:      sessGuid,
 			Data:         meta_data,
-			Rtt:          math.Abs(float64(time - nodeUpdates.Timestamp)),
+			Rtt:          rtt,
 			Communicator: sender,
 			Ej:           nodeUpdates.Ej,
 		}
@@ -105,10 +150,10 @@ func executePull(nodeCore core.GNCFDCoreInteractionGate, nodeUpdates *pb_go.Node
 		if err != nil {
 			return fmt.Errorf("error in data translation, details: %s", err)
 		}
-		meta := vivaldi.VivaldiMetadata[complex128]{
+		meta := &vivaldi.VivaldiMetadata[complex128]{
 			Session:      sessGuid,
 			Data:         meta_data,
-			Rtt:          math.Abs(float64(time - nodeUpdates.Timestamp)),
+			Rtt:          rtt,
 			Communicator: sender,
 			Ej:           nodeUpdates.Ej,
 		}
@@ -132,14 +177,20 @@ func (gc *VivaldiRPCGossipClient) Push(nodeCore core.GNCFDCoreInteractionGate, c
 
 	pointsToSend.MessageID = messageID.String()
 
+	rtt, err := gc.measureRtt()
+	if err != nil {
+		return fmt.Errorf("error in rtt measurement, details: %s", err)
+	}
+	pointsToSend.Rtt = rtt
+
 	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	time, err := ntptime.GetNTPTime()
+	now, err := ntptime.GetNTPTime()
 	if err != nil {
 		return fmt.Errorf("error in parameters preparation, details: %s", err)
 	}
-	pointsToSend.Timestamp = time.UnixNano()
+	pointsToSend.Timestamp = now.UnixNano()
 
 	_, err = gc.client.PushGossip(timeout, pointsToSend)
 	if err != nil {
@@ -155,6 +206,11 @@ func (gc *VivaldiRPCGossipClient) Pull(nodeCore core.GNCFDCoreInteractionGate) e
 		return errors.New("error: the requested core is incompatible with this gossip client")
 	}
 
+	rtt, err := gc.measureRtt()
+	if err != nil {
+		return fmt.Errorf("error in rtt measurement, details: %s", err)
+	}
+
 	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -163,13 +219,7 @@ func (gc *VivaldiRPCGossipClient) Pull(nodeCore core.GNCFDCoreInteractionGate) e
 		return fmt.Errorf("error in pull invocation, details: %s", err)
 	}
 
-	nowTime, err := ntptime.GetNTPTime()
-	if err != nil {
-		return fmt.Errorf("error in timestamp creation, details: %s", err)
-	}
-	now := nowTime.UnixNano()
-
-	return executePull(nodeCore, nodeUpdates, now)
+	return executePull(nodeCore, nodeUpdates, rtt)
 }
 
 func (vgc *VivaldiRPCGossipClient) Exchange(nodeCore core.GNCFDCoreInteractionGate, coreData core.CoreData, messageID guid.Guid) error {
@@ -181,26 +231,27 @@ func (vgc *VivaldiRPCGossipClient) Exchange(nodeCore core.GNCFDCoreInteractionGa
 
 	pointsToSend.MessageID = messageID.String()
 
+	rtt, err := vgc.measureRtt()
+	if err != nil {
+		return fmt.Errorf("error in rtt measurement, details: %s", err)
+	}
+	pointsToSend.Rtt = rtt
+
 	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	time, err := ntptime.GetNTPTime()
+	now, err := ntptime.GetNTPTime()
 	if err != nil {
 		return fmt.Errorf("error in parameters preparation, details: %s", err)
 	}
-	pointsToSend.Timestamp = time.UnixNano()
+	pointsToSend.Timestamp = now.UnixNano()
 
 	nodeUpdates, err := vgc.client.ExchangeGossip(timeout, pointsToSend)
 	if err != nil {
 		return fmt.Errorf("unable to push state updates, details: %s", err)
 	}
 
-	time, err = ntptime.GetNTPTime()
-	if err != nil {
-		return fmt.Errorf("error in parameters preparation, details: %s", err)
-	}
-
-	return executePull(nodeCore, nodeUpdates, time.UnixNano())
+	return executePull(nodeCore, nodeUpdates, rtt)
 }
 
 func (vgc *VivaldiRPCGossipClient) Forward(nodeCore core.GNCFDCoreInteractionGate, data core.CoreData) error {
@@ -209,30 +260,39 @@ func (vgc *VivaldiRPCGossipClient) Forward(nodeCore core.GNCFDCoreInteractionGat
 		return errors.New("error: the requested core is incompatible with this gossip client")
 	}
 
-	nodes, ok := data.(*pb_go.NodeUpdates)
+	received, ok := data.(*pb_go.NodeUpdates)
 	if !ok {
 		return errors.New("error: bad message passed")
 	}
+	//The received message is shared with the other observers, only a copy is changed
+	nodes := proto.Clone(received).(*pb_go.NodeUpdates)
 
 	coreStatus, _ := nodeCore.GetMyState()
 	switch coreStatusReal := coreStatus.(type) {
 	case *vivaldi.VivaldiPeerState[float64]:
 		if nodes.Support == pb_go.Support_REAL {
 			coordinates := coreStatusReal.Coords
-			point := asPointFloat(coordinates)
+			point := asPointFloat(coordinates, coreStatusReal.SpaceKind)
 			found := false
 			for _, nodeState := range nodes.UpdatePayload {
 				if nodeState.Guid == coreStatusReal.Me.String() {
 					nodeState.Coords = point
+					nodeState.Failed = false
+					nodeState.Incarnation = coreStatusReal.Incarnation
+					nodeState.Error = coreStatusReal.Ej
+					nodeState.LastUpdate = asUnixNano(coreStatusReal.LastUpdate)
 					found = true
 					break
 				}
 			}
 			if !found {
 				toAppend := &pb_go.NodeState{
-					Guid:   coreStatusReal.Me.String(),
-					Failed: false,
-					Coords: point}
+					Guid:        coreStatusReal.Me.String(),
+					Failed:      false,
+					Incarnation: coreStatusReal.Incarnation,
+					Error:       coreStatusReal.Ej,
+					LastUpdate:  asUnixNano(coreStatusReal.LastUpdate),
+					Coords:      point}
 				nodes.UpdatePayload = append(nodes.UpdatePayload, toAppend)
 			}
 			nodes.Sender = coreStatusReal.Me.String()
@@ -249,15 +309,22 @@ func (vgc *VivaldiRPCGossipClient) Forward(nodeCore core.GNCFDCoreInteractionGat
 			for _, nodeState := range nodes.UpdatePayload {
 				if nodeState.Guid == coreStatusReal.Me.String() {
 					nodeState.Coords = point
+					nodeState.Failed = false
+					nodeState.Incarnation = coreStatusReal.Incarnation
+					nodeState.Error = coreStatusReal.Ej
+					nodeState.LastUpdate = asUnixNano(coreStatusReal.LastUpdate)
 					found = true
 					break
 				}
 			}
 			if !found {
 				toAppend := &pb_go.NodeState{
-					Guid:   coreStatusReal.Me.String(),
-					Failed: false,
-					Coords: point}
+					Guid:        coreStatusReal.Me.String(),
+					Failed:      false,
+					Incarnation: coreStatusReal.Incarnation,
+					Error:       coreStatusReal.Ej,
+					LastUpdate:  asUnixNano(coreStatusReal.LastUpdate),
+					Coords:      point}
 				nodes.UpdatePayload = append(nodes.UpdatePayload, toAppend)
 			}
 			nodes.Sender = coreStatusReal.Me.String()
@@ -269,14 +336,20 @@ func (vgc *VivaldiRPCGossipClient) Forward(nodeCore core.GNCFDCoreInteractionGat
 		return errors.New("error: got bad state from core")
 	}
 
+	rtt, err := vgc.recentRtt()
+	if err != nil {
+		return fmt.Errorf("error in rtt measurement, details: %s", err)
+	}
+	nodes.Rtt = rtt
+
 	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	time, err := ntptime.GetNTPTime()
+	now, err := ntptime.GetNTPTime()
 	if err != nil {
 		return fmt.Errorf("error in parameters preparation, details: %s", err)
 	}
-	nodes.Timestamp = time.UnixNano()
+	nodes.Timestamp = now.UnixNano()
 
 	_, err = vgc.client.PushGossip(timeout, nodes)
 
@@ -0,0 +1,257 @@
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/pb_go"
+	"github.com/sebastianopriscan/GNCFD/core"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+	lockedmap "github.com/sebastianopriscan/GNCFD/utils/locked_map"
+	"google.golang.org/protobuf/proto"
+)
+
+type cmplxNode struct {
+	me      guid.Guid
+	core    *vivaldi.VivaldiCore[complex128]
+	address string
+}
+
+// startCmplxNode serves a complex core of session on a free local port
+func startCmplxNode(t *testing.T, me guid.Guid, session guid.Guid, coords []complex128) *cmplxNode {
+	space, err := nvs.NewUnitarySpace(len(coords))
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	nodeCore, err := vivaldi.NewVivaldiCore(me, coords, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	nodeCore.SetCoreSession(session)
+
+	return &cmplxNode{me: me, core: nodeCore, address: serveCore(t, me, session, nodeCore)}
+}
+
+// serveCore serves the core of node me for session on a free local port and returns its address
+func serveCore(t *testing.T, me guid.Guid, session guid.Guid, nodeCore core.GNCFDCoreInteractionGate) string {
+	coreMap := &lockedmap.LockedMap[guid.Guid, core.GNCFDCoreInteractionGate]{
+		Map: map[guid.Guid]core.GNCFDCoreInteractionGate{session: nodeCore},
+	}
+
+	name := fmt.Sprintf("%s-%x", t.Name(), me[:])
+	serv, _, err := connectionmanager.GetServer(name, "127.0.0.1:0", "tcp", nil)
+	if err != nil {
+		t.Fatalf("Unable to create server, details: %s", err)
+	}
+	RegisterVivaldiGRPCServer(serv, coreMap)
+	serv.Start()
+
+	t.Cleanup(func() {
+		connectionmanager.ReleaseServerUsage(serv)
+		connectionmanager.DestroyServer(name)
+	})
+
+	return serv.Conn.Addr().String()
+}
+
+func newClient(t *testing.T, peer *cmplxNode) *VivaldiRPCGossipClient {
+	client, err := NewVivaldiRPCGossipClient(peer.me, peer.address)
+	if err != nil {
+		t.Fatalf("Unable to create client, details: %s", err)
+	}
+	t.Cleanup(func() { client.Release() })
+
+	return client
+}
+
+func checkKnows(t *testing.T, observer *cmplxNode, node guid.Guid, expected []complex128) {
+	known, ok := observer.core.Snapshot().Nodes[node]
+	if !ok {
+		t.Fatalf("Node %x does not know node %x", observer.me[:], node[:])
+	}
+	if len(known.Coords) != len(expected) {
+		t.Fatalf("Expected %d coordinates, got %d", len(expected), len(known.Coords))
+	}
+	for i := range expected {
+		if known.Coords[i] != expected[i] {
+			t.Fatalf("Expected coordinates %v, got %v", expected, known.Coords)
+		}
+	}
+}
+
+func TestCmplxGossip(t *testing.T) {
+	session := guid.Guid{42}
+	first := startCmplxNode(t, guid.Guid{1}, session, []complex128{1 + 2i, -1i})
+	second := startCmplxNode(t, guid.Guid{2}, session, []complex128{3, 2 + 1i})
+
+	firstCoords := first.core.Snapshot().Coords
+	updates, err := first.core.GetStateUpdates()
+	if err != nil {
+		t.Fatalf("Unable to get state updates, details: %s", err)
+	}
+	if err = newClient(t, second).Push(first.core, updates, guid.Guid{100}); err != nil {
+		t.Fatalf("Unable to push, details: %s", err)
+	}
+
+	//The imaginary parts travel with the real ones
+	checkKnows(t, second, first.me, firstCoords)
+	if coords := second.core.Snapshot().Coords; coords[0] == 3 && coords[1] == 2+1i {
+		t.Fatalf("The push did not move the receiver")
+	}
+
+	secondCoords := second.core.Snapshot().Coords
+	if err = newClient(t, second).Pull(first.core); err != nil {
+		t.Fatalf("Unable to pull, details: %s", err)
+	}
+	checkKnows(t, first, second.me, secondCoords)
+
+	firstCoords = first.core.Snapshot().Coords
+	updates, err = first.core.GetStateUpdates()
+	if err != nil {
+		t.Fatalf("Unable to get state updates, details: %s", err)
+	}
+	if err = newClient(t, second).Exchange(first.core, updates, guid.Guid{101}); err != nil {
+		t.Fatalf("Unable to exchange, details: %s", err)
+	}
+
+	//The server replies with the coordinates it reached handling the pushed half
+	checkKnows(t, second, first.me, firstCoords)
+	checkKnows(t, first, second.me, second.core.Snapshot().Coords)
+
+	for _, node := range []*cmplxNode{first, second} {
+		estimate, err := node.core.EstimateRTT(first.me, second.me)
+		if err != nil {
+			t.Fatalf("Unable to estimate rtt, details: %s", err)
+		}
+		if estimate.RTT <= 0 {
+			t.Fatalf("Expected a positive rtt estimate, got %f", estimate.RTT)
+		}
+	}
+}
+
+func TestForward(t *testing.T) {
+	session := guid.Guid{44}
+	origin := startCmplxNode(t, guid.Guid{6}, session, []complex128{1, 1i})
+	forwarder := startCmplxNode(t, guid.Guid{7}, session, []complex128{2, 2i})
+	receiver := startCmplxNode(t, guid.Guid{8}, session, []complex128{3, 3i})
+
+	updates, err := origin.core.GetStateUpdates()
+	if err != nil {
+		t.Fatalf("Unable to get state updates, details: %s", err)
+	}
+	received, err := preparePush(origin.core, updates)
+	if err != nil {
+		t.Fatalf("Unable to prepare push, details: %s", err)
+	}
+	received.MessageID = guid.Guid{103}.String()
+	original := proto.Clone(received)
+
+	client := newClient(t, receiver)
+	if err = client.Forward(forwarder.core, received); err != nil {
+		t.Fatalf("Unable to forward, details: %s", err)
+	}
+	measuredAt := client.lastRttAt
+	if err = client.Forward(forwarder.core, received); err != nil {
+		t.Fatalf("Unable to forward, details: %s", err)
+	}
+
+	//The other observers of the received message still see it as it arrived
+	if !proto.Equal(received, original) {
+		t.Fatalf("Forward changed the received message")
+	}
+	if client.lastRttAt != measuredAt {
+		t.Fatalf("The second forward pinged the peer again")
+	}
+	checkKnows(t, receiver, origin.me, origin.core.Snapshot().Coords)
+	checkKnows(t, receiver, forwarder.me, forwarder.core.Snapshot().Coords)
+	if known := receiver.core.Snapshot().Nodes[forwarder.me]; !known.LastUpdate.Equal(forwarder.core.Snapshot().LastUpdate) {
+		t.Fatalf("The forwarder did not send its last update time, got %v", known.LastUpdate)
+	}
+}
+
+func TestCmplxGossipRejectsReal(t *testing.T) {
+	session := guid.Guid{43}
+	cmplx := startCmplxNode(t, guid.Guid{3}, session, []complex128{1i, 1})
+
+	space, err := nvs.NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+	real, err := vivaldi.NewVivaldiCore(guid.Guid{4}, []float64{1., 1.}, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	real.SetCoreSession(session)
+
+	updates, err := real.GetStateUpdates()
+	if err != nil {
+		t.Fatalf("Unable to get state updates, details: %s", err)
+	}
+	if err = newClient(t, cmplx).Push(real, updates, guid.Guid{102}); err == nil {
+		t.Fatalf("Expected the complex core to refuse real coordinates")
+	}
+	if _, ok := cmplx.core.Snapshot().Nodes[guid.Guid{4}]; ok {
+		t.Fatalf("The complex core learnt a real node")
+	}
+}
+
+func TestMalformedCmplxPoint(t *testing.T) {
+	_, err := asNodeDataCmplx([]*pb_go.NodeState{{
+		Guid:   guid.Guid{5}.String(),
+		Coords: &pb_go.Point{Dimension: 2, CoordReal: &pb_go.CoordStream{Coords: []float64{1., 2.}}},
+	}})
+	if err == nil {
+		t.Fatalf("Expected a point without imaginary parts to be refused")
+	}
+}
+
+func TestMalformedRealPush(t *testing.T) {
+	session, me, sender := guid.Guid{45}, guid.Guid{9}, guid.Guid{10}
+	space, err := nvs.NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+	nodeCore, err := vivaldi.NewVivaldiCore(me, []float64{0., 0.}, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	nodeCore.SetCoreSession(session)
+
+	client, err := NewVivaldiRPCGossipClient(me, serveCore(t, me, session, nodeCore))
+	if err != nil {
+		t.Fatalf("Unable to create client, details: %s", err)
+	}
+	t.Cleanup(func() { client.Release() })
+
+	for _, point := range []*pb_go.Point{
+		nil,
+		{Dimension: 2},
+		{Dimension: 3, CoordReal: &pb_go.CoordStream{Coords: []float64{1., 2.}}},
+		{Dimension: 2, CoordReal: &pb_go.CoordStream{Coords: []float64{1., 2.}}, CoordIm: &pb_go.CoordStream{Coords: []float64{1., 2.}}},
+	} {
+		timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, err = client.client.PushGossip(timeout, &pb_go.NodeUpdates{
+			CoreSession: session.String(), MessageID: guid.Guid{104}.String(), Sender: sender.String(),
+			Support: pb_go.Support_REAL, Rtt: 1., Ej: 1.,
+			UpdatePayload: []*pb_go.NodeState{{Guid: sender.String(), Coords: point}},
+		})
+		cancel()
+		if err == nil {
+			t.Fatalf("Expected the malformed point %v to be refused", point)
+		}
+	}
+
+	//The server survived and learnt nothing
+	if _, err = client.measureRtt(); err != nil {
+		t.Fatalf("Server unreachable after the malformed pushes, details: %s", err)
+	}
+	if _, ok := nodeCore.Snapshot().Nodes[sender]; ok {
+		t.Fatalf("The core learnt a malformed node")
+	}
+}
@@ -2,15 +2,24 @@ package endpoints
 
 import (
 	"errors"
+	"time"
 
 	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/pb_go"
 	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
 	"github.com/sebastianopriscan/GNCFD/utils/guid"
 )
 
 const core_code string = "Vivaldi"
 
-func asPointFloat(coordinates []float64) *pb_go.Point {
+func asPointFloat(coordinates []float64, spaceKind string) *pb_go.Point {
+	if spaceKind == nvs.HeightVectorKind {
+		last := len(coordinates) - 1
+		height := coordinates[last]
+		coordReal := &pb_go.CoordStream{Coords: coordinates[:last]}
+		return &pb_go.Point{Dimension: int64(last), CoordReal: coordReal, Height: &height}
+	}
+
 	coordReal := &pb_go.CoordStream{Coords: coordinates}
 	return &pb_go.Point{Dimension: int64(len(coordinates)), CoordReal: coordReal}
 }
@@ -20,13 +29,29 @@ func asPointsFloat(updates *vivaldi.VivaldiMetadata[float64]) []*pb_go.NodeState
 
 	for k, v := range updates.Data {
 		coordinates := v.Coords
-		point := asPointFloat(coordinates)
-		retVal = append(retVal, &pb_go.NodeState{Guid: k.String(), Coords: point, Failed: v.IsFailed})
+		point := asPointFloat(coordinates, updates.SpaceKind)
+		retVal = append(retVal, &pb_go.NodeState{Guid: k.String(), Coords: point, Failed: v.IsFailed, Left: v.HasLeft, Incarnation: v.Incarnation,
+			Error: v.Error, LastUpdate: asUnixNano(v.LastUpdate)})
 	}
 
 	return retVal
 }
 
+// asUnixNano maps the zero time, meaning unknown, to 0
+func asUnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+func asTime(unixNano int64) time.Time {
+	if unixNano == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, unixNano)
+}
+
 func asPointCmplx(coordinates []complex128) *pb_go.Point {
 	re_coords := make([]float64, 0)
 	im_coords := make([]float64, 0)
@@ -47,7 +72,8 @@ func asPointsCmplx(updates *vivaldi.VivaldiMetadata[complex128]) []*pb_go.NodeSt
 	for k, v := range updates.Data {
 		coordinates := v.Coords
 		point := asPointCmplx(coordinates)
-		retVal = append(retVal, &pb_go.NodeState{Guid: k.String(), Coords: point, Failed: v.IsFailed})
+		retVal = append(retVal, &pb_go.NodeState{Guid: k.String(), Coords: point, Failed: v.IsFailed, Left: v.HasLeft, Incarnation: v.Incarnation,
+			Error: v.Error, LastUpdate: asUnixNano(v.LastUpdate)})
 	}
 
 	return retVal
@@ -65,7 +91,21 @@ func asNodeDataReal(array []*pb_go.NodeState) (map[guid.Guid]vivaldi.VivaldiMeta
 
 		nodeData := vivaldi.VivaldiMetaCoor[float64]{}
 		nodeData.IsFailed = array[i].Failed
-		nodeData.Coords = array[i].Coords.CoordReal.Coords
+		nodeData.HasLeft = array[i].Left
+		nodeData.Incarnation = array[i].Incarnation
+		nodeData.Error = array[i].Error
+		nodeData.LastUpdate = asTime(array[i].LastUpdate)
+
+		point := array[i].Coords
+		if point == nil || point.CoordReal == nil || point.CoordIm != nil ||
+			int64(len(point.CoordReal.Coords)) != point.Dimension {
+			return nil, errors.New("error: malformed real point")
+		}
+
+		nodeData.Coords = point.CoordReal.Coords
+		if point.Height != nil {
+			nodeData.Coords = append(append(make([]float64, 0, len(nodeData.Coords)+1), nodeData.Coords...), *point.Height)
+		}
 
 		retVal[guid] = nodeData
 	}
@@ -85,6 +125,16 @@ func asNodeDataCmplx(array []*pb_go.NodeState) (map[guid.Guid]vivaldi.VivaldiMet
 
 		nodeData := vivaldi.VivaldiMetaCoor[complex128]{}
 		nodeData.IsFailed = array[i].Failed
+		nodeData.HasLeft = array[i].Left
+		nodeData.Incarnation = array[i].Incarnation
+		nodeData.Error = array[i].Error
+		nodeData.LastUpdate = asTime(array[i].LastUpdate)
+
+		point := array[i].Coords
+		if point == nil || point.CoordReal == nil || point.CoordIm == nil ||
+			int64(len(point.CoordReal.Coords)) < point.Dimension || int64(len(point.CoordIm.Coords)) < point.Dimension {
+			return nil, errors.New("error: malformed complex point")
+		}
 
 		cmplxCoords := make([]complex128, 0)
 
@@ -0,0 +1,272 @@
+package endpoints
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/pb_go"
+	"github.com/sebastianopriscan/GNCFD/core"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+	lockedmap "github.com/sebastianopriscan/GNCFD/utils/locked_map"
+)
+
+// MembershipSession is what a node exposes to the nodes joining or leaving one of its sessions
+type MembershipSession struct {
+	Core     core.GNCFDCoreInteractionGate
+	Gossiper *gossip.BlindCounterGossiper
+
+	// Optional: called instead of adding the newcomer to the gossiper, e.g. to insert it in a sampled view
+	OnJoin func(peer guid.Guid, address string)
+	// Optional: called instead of removing the leaving node from the gossiper
+	OnLeave func(peer guid.Guid)
+}
+
+type VivaldiGRPCMembershipServer struct {
+	pb_go.UnimplementedMembershipServer
+	sessions *lockedmap.LockedMap[guid.Guid, *MembershipSession]
+}
+
+// RegisterVivaldiMembershipServer adds the membership service to serv, which must not be started yet
+func RegisterVivaldiMembershipServer(serv *connectionmanager.ServerInterface, sessions *lockedmap.LockedMap[guid.Guid, *MembershipSession]) *VivaldiGRPCMembershipServer {
+	membServ := &VivaldiGRPCMembershipServer{sessions: sessions}
+	pb_go.RegisterMembershipServer(serv.Server, membServ)
+	return membServ
+}
+
+// PeerAddress is a peer of the session with the address to reach it
+type PeerAddress struct {
+	Guid    guid.Guid
+	Address string
+}
+
+// JoinInfo is what a newcomer needs to set up its core
+type JoinInfo struct {
+	Session     guid.Guid
+	Seed        guid.Guid
+	Support     pb_go.Support
+	SpaceKind   string
+	Dimension   int
+	Incarnation uint64
+	Peers       []PeerAddress
+}
+
+func (vms *VivaldiGRPCMembershipServer) session(coreSession string) (guid.Guid, *MembershipSession, error) {
+	vms.sessions.Mu.RLock()
+	defer vms.sessions.Mu.RUnlock()
+
+	if coreSession == "" {
+		if len(vms.sessions.Map) != 1 {
+			return guid.Guid{}, nil, errors.New("error: the session is mandatory when the seed hosts more than one")
+		}
+		for sessGuid, session := range vms.sessions.Map {
+			return sessGuid, session, nil
+		}
+	}
+
+	sessGuid, err := guid.Deserialize([]byte(coreSession))
+	if err != nil {
+		return guid.Guid{}, nil, errors.New("error converting guid")
+	}
+
+	session, ok := vms.sessions.Map[sessGuid]
+	if !ok {
+		return guid.Guid{}, nil, errors.New("error: no core with such session")
+	}
+
+	return sessGuid, session, nil
+}
+
+func (vms *VivaldiGRPCMembershipServer) Join(ctx context.Context, request *pb_go.JoinRequest) (*pb_go.JoinReply, error) {
+
+	sessGuid, session, err := vms.session(request.CoreSession)
+	if err != nil {
+		return nil, fmt.Errorf("%s, join failed", err)
+	}
+
+	if session.Core.GetKind() != core_code {
+		return nil, errors.New("error: requested core incompatible with the membership service, join failed")
+	}
+
+	newcomer, err := guid.Deserialize([]byte(request.Guid))
+	if err != nil {
+		return nil, errors.New("error deserializing newcomer guid, join failed")
+	}
+	if request.Address == "" {
+		return nil, errors.New("error: the newcomer address is mandatory, join failed")
+	}
+
+	reply := &pb_go.JoinReply{CoreSession: sessGuid.String()}
+
+	myState, err := session.Core.GetMyState()
+	if err != nil {
+		return nil, fmt.Errorf("error getting core state, join failed, details: %s", err)
+	}
+	dimension := 0
+	switch state := myState.(type) {
+	case *vivaldi.VivaldiPeerState[float64]:
+		reply.Support = pb_go.Support_REAL
+		reply.SpaceKind = state.SpaceKind
+		reply.SeedGuid = state.Me.String()
+		dimension = len(state.Coords)
+	case *vivaldi.VivaldiPeerState[complex128]:
+		reply.Support = pb_go.Support_CMPLX
+		reply.SpaceKind = state.SpaceKind
+		reply.SeedGuid = state.Me.String()
+		dimension = len(state.Coords)
+	default:
+		return nil, errors.New("error: wrong core state format, join failed")
+	}
+	if reply.SpaceKind == nvs.HeightVectorKind {
+		dimension--
+	}
+	reply.Dimension = uint32(dimension)
+
+	//A node coming back should start above whatever the session remembers of it
+	if incarnationCore, ok := session.Core.(interface {
+		GetIncarnation(guid.Guid) (uint64, bool)
+	}); ok {
+		if incarnation, known := incarnationCore.GetIncarnation(newcomer); known {
+			reply.Incarnation = incarnation + 1
+		}
+	}
+
+	for peer, address := range session.Gossiper.PeerAddresses() {
+		if request.MaxPeers > 0 && len(reply.Peers) == int(request.MaxPeers) {
+			break
+		}
+		if peer != newcomer {
+			reply.Peers = append(reply.Peers, &pb_go.PeerAddress{Guid: peer.String(), Address: address})
+		}
+	}
+
+	if session.OnJoin != nil {
+		session.OnJoin(newcomer, request.Address)
+	} else {
+		client, err := NewVivaldiRPCGossipClient(newcomer, request.Address)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to the newcomer, join failed, details: %s", err)
+		}
+		session.Gossiper.AddPeer(newcomer, client)
+	}
+
+	return reply, nil
+}
+
+func (vms *VivaldiGRPCMembershipServer) Leave(ctx context.Context, request *pb_go.LeaveRequest) (*pb_go.LeaveReply, error) {
+
+	_, session, err := vms.session(request.CoreSession)
+	if err != nil {
+		return nil, fmt.Errorf("%s, leave failed", err)
+	}
+
+	leaving, err := guid.Deserialize([]byte(request.Guid))
+	if err != nil {
+		return nil, errors.New("error deserializing leaving node guid, leave failed")
+	}
+
+	session.Core.SignalLeft(leaving, request.Incarnation)
+
+	if session.OnLeave != nil {
+		session.OnLeave(leaving)
+	} else {
+		session.Gossiper.RemovePeer(leaving)
+	}
+
+	return &pb_go.LeaveReply{}, nil
+}
+
+// Join asks the node at seedAddress to let me, reachable at myAddress, into a session.
+// An empty session joins the only session of the seed
+func Join(seedAddress string, session string, me guid.Guid, myAddress string, maxPeers int) (*JoinInfo, error) {
+
+	//The seed guid is unknown, the connection is keyed by a throwaway one
+	connKey, err := guid.GenerateGUID()
+	if err != nil {
+		return nil, fmt.Errorf("error generating connection guid, details: %s", err)
+	}
+	conn, err := connectionmanager.NewGrpcCommunicationChannel(connKey, seedAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error in obtaining connection to the seed, details: %s", err)
+	}
+	defer connectionmanager.InvalidateGrpcCommunicationChannel(conn)
+
+	timeout, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reply, err := pb_go.NewMembershipClient(conn.Conn).Join(timeout, &pb_go.JoinRequest{
+		CoreSession: session,
+		Guid:        me.String(),
+		Address:     myAddress,
+		MaxPeers:    uint32(maxPeers),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error in join invocation, details: %s", err)
+	}
+
+	retVal := &JoinInfo{
+		Support:     reply.Support,
+		SpaceKind:   reply.SpaceKind,
+		Dimension:   int(reply.Dimension),
+		Incarnation: reply.Incarnation,
+	}
+	if retVal.Session, err = guid.Deserialize([]byte(reply.CoreSession)); err != nil {
+		return nil, errors.New("error deserializing session guid")
+	}
+	if retVal.Seed, err = guid.Deserialize([]byte(reply.SeedGuid)); err != nil {
+		return nil, errors.New("error deserializing seed guid")
+	}
+	for _, peer := range reply.Peers {
+		peerGuid, err := guid.Deserialize([]byte(peer.Guid))
+		if err != nil {
+			return nil, errors.New("error deserializing peer guid")
+		}
+		retVal.Peers = append(retVal.Peers, PeerAddress{Guid: peerGuid, Address: peer.Address})
+	}
+
+	return retVal, nil
+}
+
+// Leave tells every peer that me is leaving the session on purpose, so that
+// they drop it instead of suspecting it
+func Leave(peers map[guid.Guid]string, session guid.Guid, me guid.Guid, incarnation uint64) error {
+
+	var err error
+	for peer, address := range peers {
+		if leaveErr := leave(peer, address, session, me, incarnation); leaveErr != nil {
+			log.Printf("error leaving through %v, details: %s\n", peer, leaveErr)
+			err = errors.New("error: at least a peer was not notified")
+		}
+	}
+
+	return err
+}
+
+func leave(peer guid.Guid, address string, session guid.Guid, me guid.Guid, incarnation uint64) error {
+
+	conn, err := connectionmanager.NewGrpcCommunicationChannel(peer, address)
+	if err != nil {
+		return fmt.Errorf("error in obtaining connection, details: %s", err)
+	}
+	defer connectionmanager.InvalidateGrpcCommunicationChannel(conn)
+
+	timeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = pb_go.NewMembershipClient(conn.Conn).Leave(timeout, &pb_go.LeaveRequest{
+		CoreSession: session.String(),
+		Guid:        me.String(),
+		Incarnation: incarnation,
+	})
+	if err != nil {
+		return fmt.Errorf("error in leave invocation, details: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package endpoints
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/sebastianopriscan/GNCFD/communication"
+	connectionmanager "github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/connection_manager"
+	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/pb_go"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+	lockedmap "github.com/sebastianopriscan/GNCFD/utils/locked_map"
+)
+
+type seedNode struct {
+	me       guid.Guid
+	core     *vivaldi.VivaldiCore[float64]
+	gossiper *gossip.BlindCounterGossiper
+	address  string
+}
+
+// startSeed serves the membership of a real core of session on a free local port,
+// configure is called on the session before the server starts
+func startSeed(t *testing.T, me guid.Guid, session guid.Guid, configure func(*MembershipSession)) *seedNode {
+	space, err := nvs.NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	nodeCore, err := vivaldi.NewVivaldiCore(me, []float64{0., 0.}, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	nodeCore.SetCoreSession(session)
+
+	peers := &lockedmap.LockedMap[guid.Guid, communication.GNCFDCommunicationChannel]{
+		Map: make(map[guid.Guid]communication.GNCFDCommunicationChannel),
+	}
+	gossiper := gossip.NewBlindCounterGossiper(peers, nodeCore, 1, 1)
+
+	membership := &MembershipSession{Core: nodeCore, Gossiper: gossiper}
+	if configure != nil {
+		configure(membership)
+	}
+
+	name := fmt.Sprintf("%s-%x", t.Name(), me[:])
+	serv, _, err := connectionmanager.GetServer(name, "127.0.0.1:0", "tcp", nil)
+	if err != nil {
+		t.Fatalf("Unable to create server, details: %s", err)
+	}
+	RegisterVivaldiMembershipServer(serv, &lockedmap.LockedMap[guid.Guid, *MembershipSession]{
+		Map: map[guid.Guid]*MembershipSession{session: membership},
+	})
+	serv.Start()
+
+	t.Cleanup(func() {
+		for _, peer := range gossiper.Peers() {
+			gossiper.RemovePeer(peer)
+		}
+		connectionmanager.ReleaseServerUsage(serv)
+		connectionmanager.DestroyServer(name)
+	})
+
+	return &seedNode{me: me, core: nodeCore, gossiper: gossiper, address: serv.Conn.Addr().String()}
+}
+
+func TestJoin(t *testing.T) {
+	session := guid.Guid{50}
+	seed := startSeed(t, guid.Guid{51}, session, nil)
+	newcomer, known := guid.Guid{52}, guid.Guid{53}
+
+	//The seed remembers a previous life of the newcomer and gossips with another peer
+	err := seed.core.UpdateState(&vivaldi.VivaldiMetadata[float64]{
+		Session: session,
+		Data: map[guid.Guid]vivaldi.VivaldiMetaCoor[float64]{
+			newcomer: {Incarnation: 4, Coords: []float64{1., 1.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: newcomer,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+	client, err := NewVivaldiRPCGossipClient(known, "127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("Unable to create client, details: %s", err)
+	}
+	seed.gossiper.AddPeer(known, client)
+
+	info, err := Join(seed.address, "", newcomer, "127.0.0.1:2", 0)
+	if err != nil {
+		t.Fatalf("Unable to join, details: %s", err)
+	}
+
+	if info.Session != session || info.Seed != seed.me {
+		t.Fatalf("Expected session %v from seed %v, got %v from %v", session, seed.me, info.Session, info.Seed)
+	}
+	if info.Support != pb_go.Support_REAL || info.SpaceKind != nvs.EuclideanKind || info.Dimension != 2 {
+		t.Fatalf("Wrong space handed out: %v %s %d", info.Support, info.SpaceKind, info.Dimension)
+	}
+	if info.Incarnation != 5 {
+		t.Fatalf("Expected incarnation 5 above the remembered one, got %d", info.Incarnation)
+	}
+	if len(info.Peers) != 1 || info.Peers[0].Guid != known || info.Peers[0].Address != "127.0.0.1:1" {
+		t.Fatalf("Expected the seed peers without the newcomer, got %v", info.Peers)
+	}
+	if _, ok := seed.gossiper.PeerAddresses()[newcomer]; !ok {
+		t.Fatalf("The seed should gossip with the newcomer")
+	}
+
+	if _, err = Join(seed.address, guid.Guid{99}.String(), newcomer, "127.0.0.1:2", 0); err == nil {
+		t.Fatalf("Expected a join to an unknown session to fail")
+	}
+
+	if err = Leave(map[guid.Guid]string{seed.me: seed.address}, session, newcomer, 5); err != nil {
+		t.Fatalf("Unable to leave, details: %s", err)
+	}
+	if _, ok := seed.gossiper.PeerAddresses()[newcomer]; ok {
+		t.Fatalf("The seed should stop gossiping with a node that left")
+	}
+}
+
+func TestMembershipHooks(t *testing.T) {
+	session := guid.Guid{60}
+	newcomer := guid.Guid{62}
+
+	var mu sync.Mutex
+	joined, left := map[guid.Guid]string{}, map[guid.Guid]bool{}
+	seed := startSeed(t, guid.Guid{61}, session, func(ms *MembershipSession) {
+		ms.OnJoin = func(peer guid.Guid, address string) {
+			mu.Lock()
+			defer mu.Unlock()
+			joined[peer] = address
+		}
+		ms.OnLeave = func(peer guid.Guid) {
+			mu.Lock()
+			defer mu.Unlock()
+			left[peer] = true
+		}
+	})
+
+	if _, err := Join(seed.address, session.String(), newcomer, "127.0.0.1:3", 0); err != nil {
+		t.Fatalf("Unable to join, details: %s", err)
+	}
+	mu.Lock()
+	if joined[newcomer] != "127.0.0.1:3" {
+		t.Fatalf("OnJoin not called with the newcomer address, got %v", joined)
+	}
+	mu.Unlock()
+	if len(seed.gossiper.Peers()) != 0 {
+		t.Fatalf("OnJoin should replace adding the newcomer to the gossiper")
+	}
+
+	//The seed never heard from the newcomer, the tombstone has to spread anyway
+	if err := Leave(map[guid.Guid]string{seed.me: seed.address}, session, newcomer, 2); err != nil {
+		t.Fatalf("Unable to leave, details: %s", err)
+	}
+	mu.Lock()
+	if !left[newcomer] {
+		t.Fatalf("OnLeave not called for the leaving node")
+	}
+	mu.Unlock()
+
+	if !seed.core.GetHasLeft(newcomer) {
+		t.Fatalf("The seed should record the leave")
+	}
+	updates, err := seed.core.GetStateUpdates()
+	if err != nil {
+		t.Fatalf("Unable to get state updates, details: %s", err)
+	}
+	tombstone, ok := updates.(*vivaldi.VivaldiMetadata[float64]).Data[newcomer]
+	if !ok || !tombstone.HasLeft || tombstone.Incarnation != 2 {
+		t.Fatalf("Expected the tombstone at incarnation 2 to be gossiped, got %v", tombstone)
+	}
+}
@@ -18,6 +18,19 @@ type VivaldiGRPCServerDesc struct {
 	VivServ *VivaldiGRPCGossipServer
 }
 
+// RegisterVivaldiGRPCServer adds the gossip service to serv, which must not be started yet,
+// so that other services can share its listener
+func RegisterVivaldiGRPCServer(serv *connectionmanager.ServerInterface, coreMap *lockedmap.LockedMap[guid.Guid, core.GNCFDCoreInteractionGate]) *VivaldiGRPCGossipServer {
+	vivserv := &VivaldiGRPCGossipServer{
+		coreMap:                    coreMap,
+		ChannelObserverSubjectImpl: channelobserver.NewChannelObserverSubjectImpl(),
+	}
+
+	pb_go.RegisterGossipStatusServer(serv.Server, vivserv)
+
+	return vivserv
+}
+
 func ActivateVivaldiGRPCServer(name string, addr string, transport string,
 	opts []grpc.ServerOption, coreMap *lockedmap.LockedMap[guid.Guid, core.GNCFDCoreInteractionGate]) (*VivaldiGRPCServerDesc, error) {
 
@@ -26,12 +39,7 @@ func ActivateVivaldiGRPCServer(name string, addr string, transport string,
 		return nil, fmt.Errorf("error retrieving server, details: %s", err)
 	}
 
-	vivserv := &VivaldiGRPCGossipServer{
-		coreMap:                    coreMap,
-		ChannelObserverSubjectImpl: channelobserver.NewChannelObserverSubjectImpl(),
-	}
-
-	pb_go.RegisterGossipStatusServer(serv.Server, vivserv)
+	vivserv := RegisterVivaldiGRPCServer(serv, coreMap)
 	serv.Start()
 
 	return &VivaldiGRPCServerDesc{Server: serv, Exists: exist, VivServ: vivserv}, nil
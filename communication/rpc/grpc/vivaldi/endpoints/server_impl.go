@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 
 	"github.com/sebastianopriscan/GNCFD/communication/rpc/grpc/vivaldi/pb_go"
 	"github.com/sebastianopriscan/GNCFD/core"
@@ -22,7 +21,7 @@ type VivaldiGRPCGossipServer struct {
 	coreMap *lockedmap.LockedMap[guid.Guid, core.GNCFDCoreInteractionGate]
 }
 
-func do_push_gossip(nodes *pb_go.NodeUpdates, core core.GNCFDCoreInteractionGate, sessGuid guid.Guid, now int64) (*pb_go.PushReturn, error) {
+func do_push_gossip(nodes *pb_go.NodeUpdates, core core.GNCFDCoreInteractionGate, sessGuid guid.Guid) (*pb_go.PushReturn, error) {
 
 	sender, err := guid.Deserialize([]byte(nodes.Sender))
 	if err != nil {
@@ -40,7 +39,7 @@ func do_push_gossip(nodes *pb_go.NodeUpdates, core core.GNCFDCoreInteractionGate
 			Session:      sessGuid,
 			Data:         updates,
 			Communicator: sender,
-			Rtt:          math.Abs(float64(now-nodes.Timestamp)) / 2.0,
+			Rtt:          nodes.Rtt,
 			Ej:           nodes.Ej,
 		})
 		if err != nil {
@@ -55,7 +54,7 @@ func do_push_gossip(nodes *pb_go.NodeUpdates, core core.GNCFDCoreInteractionGate
 			Session:      sessGuid,
 			Data:         updates,
 			Communicator: sender,
-			Rtt:          math.Abs(float64(now-nodes.Timestamp)) / 2.0,
+			Rtt:          nodes.Rtt,
 			Ej:           nodes.Ej,
 		})
 		if err != nil {
@@ -111,12 +110,6 @@ func do_pull_gossip(core core.GNCFDCoreInteractionGate) (*pb_go.NodeUpdates, err
 
 func (vgs *VivaldiGRPCGossipServer) PushGossip(ctx context.Context, nodes *pb_go.NodeUpdates) (*pb_go.PushReturn, error) {
 
-	nowTime, err := ntptime.GetNTPTime()
-	if err != nil {
-		return &pb_go.PushReturn{}, fmt.Errorf("error in timestamp creation, details: %s", err)
-	}
-	now := nowTime.UnixNano()
-
 	sessGuid, err := guid.Deserialize([]byte(nodes.CoreSession))
 	if err != nil {
 		return &pb_go.PushReturn{}, errors.New("error converting guid, push failed")
@@ -147,7 +140,7 @@ func (vgs *VivaldiGRPCGossipServer) PushGossip(ctx context.Context, nodes *pb_go
 	//Pushing updates to channels
 	vgs.PushToChannels(&gossip.MessageToForward{MessageID: msgID, Sender: sender, Payload: nodes})
 
-	return do_push_gossip(nodes, core, sessGuid, now)
+	return do_push_gossip(nodes, core, sessGuid)
 }
 
 func (vgs *VivaldiGRPCGossipServer) PullGossip(ctx context.Context, session *pb_go.CoreSession) (*pb_go.NodeUpdates, error) {
@@ -174,33 +167,44 @@ func (vgs *VivaldiGRPCGossipServer) PullGossip(ctx context.Context, session *pb_
 
 func (vgs *VivaldiGRPCGossipServer) ExchangeGossip(ctx context.Context, nodes *pb_go.NodeUpdates) (*pb_go.NodeUpdates, error) {
 
-	nowTime, err := ntptime.GetNTPTime()
-	if err != nil {
-		return nil, fmt.Errorf("error in timestamp creation, details: %s", err)
-	}
-	now := nowTime.UnixNano()
-
-	guid, err := guid.Deserialize([]byte(nodes.CoreSession))
+	sessGuid, err := guid.Deserialize([]byte(nodes.CoreSession))
 	if err != nil {
-		return nil, errors.New("error converting guid, pull failed")
+		return nil, errors.New("error converting guid, exchange failed")
 	}
 
 	vgs.coreMap.Mu.RLock()
 	defer vgs.coreMap.Mu.RUnlock()
 
-	core, ok := vgs.coreMap.Map[guid]
+	core, ok := vgs.coreMap.Map[sessGuid]
 	if !ok {
-		return nil, errors.New("error: no core with such session, pull failed")
+		return nil, errors.New("error: no core with such session, exchange failed")
 	}
 
 	if core.GetKind() != core_code {
-		return nil, errors.New("error: requested core incompatible with sender one, pull failed")
+		return nil, errors.New("error: requested core incompatible with sender one, exchange failed")
 	}
 
-	_, err = do_push_gossip(nodes, core, guid, now)
+	msgID, err := guid.Deserialize([]byte(nodes.MessageID))
+	if err != nil {
+		return nil, errors.New("error deserializing message_id")
+	}
+
+	sender, err := guid.Deserialize([]byte(nodes.Sender))
+	if err != nil {
+		return nil, errors.New("error deserializing sender")
+	}
+
+	//The pushed half of the exchange is a rumor like any other push
+	vgs.PushToChannels(&gossip.MessageToForward{MessageID: msgID, Sender: sender, Payload: nodes})
+
+	_, err = do_push_gossip(nodes, core, sessGuid)
 	if err != nil {
 		return nil, fmt.Errorf("error: unable to push gossip, exchange failed, details: %s", err)
 	}
 
 	return do_pull_gossip(core)
 }
+
+func (vgs *VivaldiGRPCGossipServer) Ping(ctx context.Context, ping *pb_go.PingRequest) (*pb_go.PongReply, error) {
+	return &pb_go.PongReply{Nonce: ping.Nonce}, nil
+}
@@ -22,6 +22,7 @@ const (
 	GossipStatus_PushGossip_FullMethodName     = "/GossipStatus/PushGossip"
 	GossipStatus_PullGossip_FullMethodName     = "/GossipStatus/PullGossip"
 	GossipStatus_ExchangeGossip_FullMethodName = "/GossipStatus/ExchangeGossip"
+	GossipStatus_Ping_FullMethodName           = "/GossipStatus/Ping"
 )
 
 // GossipStatusClient is the client API for GossipStatus service.
@@ -31,6 +32,7 @@ type GossipStatusClient interface {
 	PushGossip(ctx context.Context, in *NodeUpdates, opts ...grpc.CallOption) (*PushReturn, error)
 	PullGossip(ctx context.Context, in *CoreSession, opts ...grpc.CallOption) (*NodeUpdates, error)
 	ExchangeGossip(ctx context.Context, in *NodeUpdates, opts ...grpc.CallOption) (*NodeUpdates, error)
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PongReply, error)
 }
 
 type gossipStatusClient struct {
@@ -71,6 +73,16 @@ func (c *gossipStatusClient) ExchangeGossip(ctx context.Context, in *NodeUpdates
 	return out, nil
 }
 
+func (c *gossipStatusClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PongReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PongReply)
+	err := c.cc.Invoke(ctx, GossipStatus_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GossipStatusServer is the server API for GossipStatus service.
 // All implementations must embed UnimplementedGossipStatusServer
 // for forward compatibility.
@@ -78,6 +90,7 @@ type GossipStatusServer interface {
 	PushGossip(context.Context, *NodeUpdates) (*PushReturn, error)
 	PullGossip(context.Context, *CoreSession) (*NodeUpdates, error)
 	ExchangeGossip(context.Context, *NodeUpdates) (*NodeUpdates, error)
+	Ping(context.Context, *PingRequest) (*PongReply, error)
 	mustEmbedUnimplementedGossipStatusServer()
 }
 
@@ -97,6 +110,9 @@ func (UnimplementedGossipStatusServer) PullGossip(context.Context, *CoreSession)
 func (UnimplementedGossipStatusServer) ExchangeGossip(context.Context, *NodeUpdates) (*NodeUpdates, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExchangeGossip not implemented")
 }
+func (UnimplementedGossipStatusServer) Ping(context.Context, *PingRequest) (*PongReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
 func (UnimplementedGossipStatusServer) mustEmbedUnimplementedGossipStatusServer() {}
 func (UnimplementedGossipStatusServer) testEmbeddedByValue()                      {}
 
@@ -172,6 +188,24 @@ func _GossipStatus_ExchangeGossip_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GossipStatus_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GossipStatusServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GossipStatus_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GossipStatusServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GossipStatus_ServiceDesc is the grpc.ServiceDesc for GossipStatus service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +225,10 @@ var GossipStatus_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExchangeGossip",
 			Handler:    _GossipStatus_ExchangeGossip_Handler,
 		},
+		{
+			MethodName: "Ping",
+			Handler:    _GossipStatus_Ping_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "gossip.proto",
@@ -75,6 +75,7 @@ type Point struct {
 	Dimension int64        `protobuf:"varint,1,opt,name=dimension,proto3" json:"dimension,omitempty"`
 	CoordReal *CoordStream `protobuf:"bytes,3,opt,name=coord_real,json=coordReal,proto3" json:"coord_real,omitempty"`
 	CoordIm   *CoordStream `protobuf:"bytes,4,opt,name=coord_im,json=coordIm,proto3,oneof" json:"coord_im,omitempty"`
+	Height    *float64     `protobuf:"fixed64,5,opt,name=height,proto3,oneof" json:"height,omitempty"`
 }
 
 func (x *Point) Reset() {
@@ -130,13 +131,20 @@ func (x *Point) GetCoordIm() *CoordStream {
 	return nil
 }
 
+func (x *Point) GetHeight() float64 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
 var File_space_proto protoreflect.FileDescriptor
 
 var file_space_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x25, 0x0a,
 	0x0b, 0x43, 0x6f, 0x6f, 0x72, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06,
 	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x63, 0x6f,
-	0x6f, 0x72, 0x64, 0x73, 0x22, 0x8d, 0x01, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c,
+	0x6f, 0x72, 0x64, 0x73, 0x22, 0xb5, 0x01, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1c,
 	0x0a, 0x09, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x03, 0x52, 0x09, 0x64, 0x69, 0x6d, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x0a,
 	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
@@ -144,12 +152,15 @@ var file_space_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x08, 0x63, 0x6f, 0x6f,
 	0x72, 0x64, 0x5f, 0x69, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x43, 0x6f,
 	0x6f, 0x72, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6f,
-	0x72, 0x64, 0x49, 0x6d, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x6f, 0x72,
-	0x64, 0x5f, 0x69, 0x6d, 0x42, 0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x62, 0x61, 0x73, 0x74, 0x69, 0x61, 0x6e, 0x6f, 0x70, 0x72, 0x69,
-	0x73, 0x63, 0x61, 0x6e, 0x2f, 0x47, 0x4e, 0x43, 0x46, 0x44, 0x2f, 0x67, 0x6f, 0x73, 0x73, 0x69,
-	0x70, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x76, 0x69, 0x76, 0x61, 0x6c,
-	0x64, 0x69, 0x2f, 0x70, 0x62, 0x5f, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x64, 0x49, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x1b, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x6f, 0x6f, 0x72, 0x64, 0x5f, 0x69,
+	0x6d, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x42, 0x42, 0x5a, 0x40,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x65, 0x62, 0x61, 0x73,
+	0x74, 0x69, 0x61, 0x6e, 0x6f, 0x70, 0x72, 0x69, 0x73, 0x63, 0x61, 0x6e, 0x2f, 0x47, 0x4e, 0x43,
+	0x46, 0x44, 0x2f, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x2f, 0x72, 0x70, 0x63, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x2f, 0x76, 0x69, 0x76, 0x61, 0x6c, 0x64, 0x69, 0x2f, 0x70, 0x62, 0x5f, 0x67, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
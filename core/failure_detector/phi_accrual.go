@@ -0,0 +1,146 @@
+package failuredetector
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+const (
+	DefaultWindowSize             = 100
+	DefaultMinStdDeviation        = 500 * time.Millisecond
+	DefaultFirstHeartbeatEstimate = 1 * time.Second
+)
+
+type arrivalWindow struct {
+	intervals []float64
+	next      int
+	full      bool
+
+	sum        float64
+	sumSquares float64
+
+	lastArrival time.Time
+}
+
+func (aw *arrivalWindow) add(interval float64) {
+	if aw.full {
+		old := aw.intervals[aw.next]
+		aw.sum -= old
+		aw.sumSquares -= old * old
+	}
+
+	aw.intervals[aw.next] = interval
+	aw.sum += interval
+	aw.sumSquares += interval * interval
+
+	aw.next = (aw.next + 1) % len(aw.intervals)
+	if aw.next == 0 {
+		aw.full = true
+	}
+}
+
+func (aw *arrivalWindow) size() int {
+	if aw.full {
+		return len(aw.intervals)
+	}
+	return aw.next
+}
+
+// PhiAccrualDetector implements the phi accrual failure detector by Hayashibara et al.:
+// instead of a boolean verdict it outputs, for every peer, a suspicion level phi
+// derived from the distribution of the inter-arrival times of its messages
+type PhiAccrualDetector struct {
+	mu sync.Mutex
+
+	windowSize             int
+	minStdDeviation        float64
+	firstHeartbeatEstimate float64
+
+	windows map[guid.Guid]*arrivalWindow
+}
+
+func NewPhiAccrualDetector(windowSize int, minStdDeviation time.Duration, firstHeartbeatEstimate time.Duration) (*PhiAccrualDetector, error) {
+	if windowSize <= 0 || minStdDeviation <= 0 || firstHeartbeatEstimate <= 0 {
+		return nil, errors.New("window size, minimum standard deviation and first heartbeat estimate should be greater than 0")
+	}
+
+	return &PhiAccrualDetector{
+		windowSize:             windowSize,
+		minStdDeviation:        float64(minStdDeviation),
+		firstHeartbeatEstimate: float64(firstHeartbeatEstimate),
+		windows:                make(map[guid.Guid]*arrivalWindow),
+	}, nil
+}
+
+// Heartbeat records the arrival of a message from peer at the given time
+func (pd *PhiAccrualDetector) Heartbeat(peer guid.Guid, at time.Time) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	window, present := pd.windows[peer]
+	if !present {
+		window = &arrivalWindow{intervals: make([]float64, pd.windowSize)}
+
+		//Seeding the window as if the peer sent with the estimated interval, so that the
+		//first heartbeats do not produce meaningless statistics
+		window.add(pd.firstHeartbeatEstimate - pd.firstHeartbeatEstimate/4)
+		window.add(pd.firstHeartbeatEstimate + pd.firstHeartbeatEstimate/4)
+		window.lastArrival = at
+
+		pd.windows[peer] = window
+		return
+	}
+
+	interval := float64(at.Sub(window.lastArrival))
+	if interval < 0 {
+		return
+	}
+
+	window.add(interval)
+	window.lastArrival = at
+}
+
+// Phi returns the suspicion level for peer at the given time, the second
+// return value is false if no message from peer has ever been recorded
+func (pd *PhiAccrualDetector) Phi(peer guid.Guid, at time.Time) (float64, bool) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	window, present := pd.windows[peer]
+	if !present {
+		return 0., false
+	}
+
+	size := float64(window.size())
+	mean := window.sum / size
+	variance := window.sumSquares/size - mean*mean
+	stdDeviation := math.Max(math.Sqrt(math.Max(variance, 0.)), pd.minStdDeviation)
+
+	elapsed := float64(at.Sub(window.lastArrival))
+
+	return phi(elapsed, mean, stdDeviation), true
+}
+
+// Forget drops every information collected about peer
+func (pd *PhiAccrualDetector) Forget(peer guid.Guid) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+
+	delete(pd.windows, peer)
+}
+
+// phi uses the logistic approximation of the normal cumulative distribution
+// function, which is numerically stable even for large elapsed times
+func phi(elapsed float64, mean float64, stdDeviation float64) float64 {
+	y := (elapsed - mean) / stdDeviation
+	e := math.Exp(-y * (1.5976 + 0.070566*y*y))
+
+	if elapsed > mean {
+		return -math.Log10(e / (1. + e))
+	}
+	return -math.Log10(1. - 1./(1.+e))
+}
@@ -0,0 +1,42 @@
+package failuredetector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func TestPhiGrowsWithSilence(t *testing.T) {
+	detector, err := NewPhiAccrualDetector(DefaultWindowSize, 100*time.Millisecond, time.Second)
+	if err != nil {
+		t.Fatalf("Unable to create detector, details: %s", err)
+	}
+
+	peer := guid.Guid{1}
+	start := time.Unix(0, 0)
+
+	if _, known := detector.Phi(peer, start); known {
+		t.Fatalf("Peer should not be known before any heartbeat")
+	}
+
+	for i := 0; i < 20; i++ {
+		detector.Heartbeat(peer, start.Add(time.Duration(i)*time.Second))
+	}
+	last := start.Add(19 * time.Second)
+
+	onTime, _ := detector.Phi(peer, last.Add(time.Second))
+	late, _ := detector.Phi(peer, last.Add(5*time.Second))
+
+	if onTime >= 1. {
+		t.Fatalf("Phi should be low when heartbeats arrive on time, got %v", onTime)
+	}
+	if late <= 8. {
+		t.Fatalf("Phi should be high after a long silence, got %v", late)
+	}
+
+	detector.Forget(peer)
+	if _, known := detector.Phi(peer, last); known {
+		t.Fatalf("Peer should have been forgotten")
+	}
+}
@@ -1,15 +1,41 @@
 package core
 
 import (
+	"time"
+
 	"github.com/sebastianopriscan/GNCFD/utils/guid"
 )
 
 type CoreData interface {
 }
 
+// NodeDistance is a node with the RTT estimated from its distance and the
+// error estimate of its coordinates, useful to weight them
+type NodeDistance struct {
+	Guid  guid.Guid
+	RTT   float64
+	Error float64
+}
+
+// QueryOptions filters the candidates of the ranked queries, a zero MaxError or
+// MaxAge disables the matching filter
+type QueryOptions struct {
+	ExcludeFailed bool
+	// Coordinates with a greater error estimate are too uncertain to be ranked
+	MaxError float64
+	// Coordinates not refreshed for longer, measured on the local clock, are considered stale
+	MaxAge time.Duration
+}
+
+// The ranked queries return the candidates sorted by increasing RTT, a nil
+// candidate slice stands for every node known by the core
 type GNCFDCore interface {
 	GetClosestOf(guids []guid.Guid) ([]guid.Guid, error)
 	GetIsFailed(guid guid.Guid) bool
+
+	GetKNearest(guids []guid.Guid, k int, opts QueryOptions) ([]NodeDistance, error)
+	GetWithinRadius(guids []guid.Guid, radius float64, opts QueryOptions) ([]NodeDistance, error)
+	GetSortedByDistance(guids []guid.Guid, opts QueryOptions) ([]NodeDistance, error)
 }
 
 type GNCFDCoreInteractionGate interface {
@@ -22,4 +48,5 @@ type GNCFDCoreInteractionGate interface {
 	UpdateState(metadata CoreData) error
 
 	SignalFailed(peers []guid.Guid)
+	SignalLeft(peer guid.Guid, incarnation uint64)
 }
@@ -7,23 +7,40 @@
 package vivaldi
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	"math"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/sebastianopriscan/GNCFD/core"
+	failuredetector "github.com/sebastianopriscan/GNCFD/core/failure_detector"
+	latencyfilter "github.com/sebastianopriscan/GNCFD/core/latency_filter"
 	"github.com/sebastianopriscan/GNCFD/core/nvs"
 	channelobserver "github.com/sebastianopriscan/GNCFD/utils/channel_observer"
 	"github.com/sebastianopriscan/GNCFD/utils/guid"
 )
 
 type nodeData[SUPPORT float64 | complex128] struct {
-	IsFailed bool
-	Coords   *nvs.Point[SUPPORT]
-	Updated  bool
-	Neighbor bool
+	IsFailed    bool
+	Incarnation uint64
+	Coords      *nvs.Point[SUPPORT]
+	Updated     bool
+	Neighbor    bool
+	//Error estimate of the node and time the node itself last moved its coordinates,
+	//initialError and the zero time until someone tells them
+	Error      float64
+	LastUpdate time.Time
+	//Local time the coordinates were last refreshed, LastUpdate comes from the clock
+	//of the node and only orders the information
+	ReceivedAt time.Time
+
+	//Tombstone of a node that left on purpose, dropped after the tombstone TTL
+	HasLeft bool
+	LeftAt  time.Time
 }
 
 type VivaldiCore[SUPPORT float64 | complex128] struct {
@@ -36,14 +53,34 @@ type VivaldiCore[SUPPORT float64 | complex128] struct {
 	myGUID        guid.Guid
 	myCoordinates *nvs.Point[SUPPORT]
 	space         *nvs.NormedVectorSpace[SUPPORT]
+	incarnation   uint64
+	lastUpdate    time.Time
 
 	session guid.Guid
 
+	detector     *failuredetector.PhiAccrualDetector
+	phiThreshold float64
+	filter       latencyfilter.LatencyFilter
+	gravity      float64
+	clock        func() time.Time
+	tombstoneTTL time.Duration
+
+	checkpoint_mu   sync.Mutex
+	checkpointchann chan bool
+
 	ce float64
 	cc float64
 	ei float64
 }
 
+const DefaultPhiThreshold = 8.
+
+// Error estimate of a node whose coordinates are still meaningless
+const initialError = 10.
+
+// Long enough for a leave to reach every node before the tombstone is dropped
+const DefaultTombstoneTTL = 5 * time.Minute
+
 func (cr *VivaldiCore[SUPPORT]) GetClosestOf(guids []guid.Guid) ([]guid.Guid, error) {
 	min_distance := math.MaxFloat64
 	var retSlice []guid.Guid
@@ -53,7 +90,7 @@ func (cr *VivaldiCore[SUPPORT]) GetClosestOf(guids []guid.Guid) ([]guid.Guid, er
 
 	for _, single_guid := range guids {
 		point, ok := cr.nodesCache[single_guid]
-		if !ok {
+		if !ok || point.HasLeft {
 			continue
 		}
 		guid_distance, err := cr.space.Distance(cr.myCoordinates, point.Coords)
@@ -72,10 +109,388 @@ func (cr *VivaldiCore[SUPPORT]) GetClosestOf(guids []guid.Guid) ([]guid.Guid, er
 	return retSlice, nil
 }
 
+func (cr *VivaldiCore[SUPPORT]) GetKNearest(guids []guid.Guid, k int, opts core.QueryOptions) ([]core.NodeDistance, error) {
+	if k < 0 {
+		return nil, errors.New("k should not be negative")
+	}
+
+	sorted, err := cr.GetSortedByDistance(guids, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sorted[:min(k, len(sorted))], nil
+}
+
+func (cr *VivaldiCore[SUPPORT]) GetWithinRadius(guids []guid.Guid, radius float64, opts core.QueryOptions) ([]core.NodeDistance, error) {
+	sorted, err := cr.GetSortedByDistance(guids, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	within := sort.Search(len(sorted), func(i int) bool { return sorted[i].RTT > radius })
+	return sorted[:within], nil
+}
+
+func (cr *VivaldiCore[SUPPORT]) GetSortedByDistance(guids []guid.Guid, opts core.QueryOptions) ([]core.NodeDistance, error) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	if guids == nil {
+		guids = make([]guid.Guid, 0, len(cr.nodesCache))
+		for k := range cr.nodesCache {
+			guids = append(guids, k)
+		}
+	}
+
+	now := cr.clock()
+	retVal := make([]core.NodeDistance, 0, len(guids))
+	seen := make(map[guid.Guid]bool, len(guids))
+	for _, single_guid := range guids {
+		point, ok := cr.nodesCache[single_guid]
+		if !ok || point.HasLeft || seen[single_guid] {
+			continue
+		}
+		seen[single_guid] = true
+		if opts.ExcludeFailed && cr.isFailed(single_guid, point) {
+			continue
+		}
+		if opts.MaxError > 0 && point.Error > opts.MaxError {
+			continue
+		}
+		if opts.MaxAge > 0 && now.Sub(point.ReceivedAt) > opts.MaxAge {
+			continue
+		}
+
+		guid_distance, err := cr.space.Distance(cr.myCoordinates, point.Coords)
+		if err != nil {
+			return nil, errors.New("the points whose distance was asked do not belong to the same space")
+		}
+		retVal = append(retVal, core.NodeDistance{Guid: single_guid, RTT: guid_distance, Error: point.Error})
+	}
+
+	//Ties are broken by guid, so that the order does not depend on the candidates order
+	sort.Slice(retVal, func(i, j int) bool {
+		if retVal[i].RTT != retVal[j].RTT {
+			return retVal[i].RTT < retVal[j].RTT
+		}
+		return bytes.Compare(retVal[i].Guid[:], retVal[j].Guid[:]) < 0
+	})
+
+	return retVal, nil
+}
+
+// RTTEstimate is a predicted RTT, Confidence goes from 0 when the coordinates
+// are meaningless to 1 when they have no error
+type RTTEstimate struct {
+	RTT        float64
+	Confidence float64
+}
+
+// EstimateRTT predicts the RTT between any two nodes known by the core, me included
+func (cr *VivaldiCore[SUPPORT]) EstimateRTT(a guid.Guid, b guid.Guid) (RTTEstimate, error) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	pointA, errorA, ok := cr.position(a)
+	if !ok {
+		return RTTEstimate{}, fmt.Errorf("error: node %v unknown", a)
+	}
+	pointB, errorB, ok := cr.position(b)
+	if !ok {
+		return RTTEstimate{}, fmt.Errorf("error: node %v unknown", b)
+	}
+
+	return cr.estimate(pointA, errorA, pointB, errorB)
+}
+
+// EstimateRTTMatrix predicts the RTT between every pair of nodes, the element
+// [i][j] refers to guids[i] and guids[j]
+func (cr *VivaldiCore[SUPPORT]) EstimateRTTMatrix(guids []guid.Guid) ([][]RTTEstimate, error) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	points := make([]*nvs.Point[SUPPORT], len(guids))
+	nodeErrors := make([]float64, len(guids))
+	for i, single_guid := range guids {
+		var ok bool
+		if points[i], nodeErrors[i], ok = cr.position(single_guid); !ok {
+			return nil, fmt.Errorf("error: node %v unknown", single_guid)
+		}
+	}
+
+	retVal := make([][]RTTEstimate, len(guids))
+	for i := range retVal {
+		retVal[i] = make([]RTTEstimate, len(guids))
+	}
+	for i := range guids {
+		retVal[i][i] = RTTEstimate{RTT: 0., Confidence: 1.}
+		for j := i + 1; j < len(guids); j++ {
+			estimate, err := cr.estimate(points[i], nodeErrors[i], points[j], nodeErrors[j])
+			if err != nil {
+				return nil, err
+			}
+			retVal[i][j], retVal[j][i] = estimate, estimate
+		}
+	}
+
+	return retVal, nil
+}
+
+// position returns the coordinates and the error estimate of a node, the caller must hold the lock
+func (cr *VivaldiCore[SUPPORT]) position(nodeGuid guid.Guid) (*nvs.Point[SUPPORT], float64, bool) {
+	if nodeGuid == cr.myGUID {
+		return cr.myCoordinates, cr.ei, true
+	}
+	node, present := cr.nodesCache[nodeGuid]
+	if !present || node.HasLeft {
+		return nil, 0., false
+	}
+	return node.Coords, node.Error, true
+}
+
+// estimate takes the distance as the RTT, the error estimates are relative so their
+// mean tells how far the prediction may be from the real RTT
+func (cr *VivaldiCore[SUPPORT]) estimate(a *nvs.Point[SUPPORT], errorA float64, b *nvs.Point[SUPPORT], errorB float64) (RTTEstimate, error) {
+	distance, err := cr.space.Distance(a, b)
+	if err != nil {
+		return RTTEstimate{}, errors.New("the points whose distance was asked do not belong to the same space")
+	}
+
+	return RTTEstimate{RTT: distance, Confidence: max(0., 1.-(errorA+errorB)/2.)}, nil
+}
+
 func (cr *VivaldiCore[SUPPORT]) GetIsFailed(guid guid.Guid) bool {
 	cr.core_mu.RLock()
 	defer cr.core_mu.RUnlock()
-	return cr.nodesCache[guid].IsFailed
+
+	node, present := cr.nodesCache[guid]
+	if !present {
+		return false
+	}
+	return cr.isFailed(guid, node)
+}
+
+// GetHasLeft tells whether the node announced it left the session, unlike a
+// failed node it is not expected to come back
+func (cr *VivaldiCore[SUPPORT]) GetHasLeft(guid guid.Guid) bool {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	node, present := cr.nodesCache[guid]
+	return present && node.HasLeft
+}
+
+// GetIncarnation returns the last incarnation known for the node, false if it is unknown
+func (cr *VivaldiCore[SUPPORT]) GetIncarnation(guid guid.Guid) (uint64, bool) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	if guid == cr.myGUID {
+		return cr.incarnation, true
+	}
+	node, present := cr.nodesCache[guid]
+	if !present {
+		return 0, false
+	}
+	return node.Incarnation, true
+}
+
+// SetIncarnation raises the incarnation of the core, so that a node coming back
+// overrides the tombstone or the suspicion left by its previous life
+func (cr *VivaldiCore[SUPPORT]) SetIncarnation(incarnation uint64) {
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.incarnation = max(cr.incarnation, incarnation)
+}
+
+func (cr *VivaldiCore[SUPPORT]) SetTombstoneTTL(ttl time.Duration) error {
+	if ttl <= 0 {
+		return errors.New("the tombstone TTL should be greater than 0")
+	}
+
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.tombstoneTTL = ttl
+	return nil
+}
+
+// isFailed trusts the local failure detector whenever the node has directly
+// communicated with us, falling back to the gossiped status otherwise
+func (cr *VivaldiCore[SUPPORT]) isFailed(nodeGuid guid.Guid, node *nodeData[SUPPORT]) bool {
+	phi, known := cr.detector.Phi(nodeGuid, cr.clock())
+	if !known {
+		return node.IsFailed
+	}
+	return phi > cr.phiThreshold
+}
+
+// GetSuspicionLevel returns the phi value computed for the given node, the
+// second return value is false if the node never communicated with us
+func (cr *VivaldiCore[SUPPORT]) GetSuspicionLevel(guid guid.Guid) (float64, bool) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	return cr.detector.Phi(guid, cr.clock())
+}
+
+func (cr *VivaldiCore[SUPPORT]) SetPhiThreshold(threshold float64) error {
+	if threshold <= 0 {
+		return errors.New("the phi threshold should be greater than 0")
+	}
+
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.phiThreshold = threshold
+	return nil
+}
+
+func (cr *VivaldiCore[SUPPORT]) SetFailureDetector(detector *failuredetector.PhiAccrualDetector) error {
+	if detector == nil {
+		return errors.New("the failure detector should not be nil")
+	}
+
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.detector = detector
+	return nil
+}
+
+// SetLatencyFilter filters the RTT samples before they move the coordinates,
+// nil feeds the raw samples
+func (cr *VivaldiCore[SUPPORT]) SetLatencyFilter(filter latencyfilter.LatencyFilter) {
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.filter = filter
+}
+
+// SetGravity pulls the coordinates toward the centroid of the known nodes after every
+// update, with strength (distance/rho)^2 as in Ledlie et al. A zero rho disables it.
+// The pull is in the unit of the RTTs: the rho of 1024 the authors use on milliseconds
+// becomes 1024*1000 on nanoseconds, as measured by the gRPC transport. Curved spaces
+// are refused, the centroid and the pull are taken along chords instead of geodesics
+func (cr *VivaldiCore[SUPPORT]) SetGravity(rho float64) error {
+	if rho < 0 {
+		return errors.New("the gravity rho should not be negative")
+	}
+	if kind := cr.space.Kind(); rho > 0 && (kind == nvs.HyperbolicKind || kind == nvs.SphericalKind) {
+		return fmt.Errorf("error: gravity is not supported in a %s space", kind)
+	}
+
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.gravity = rho
+	return nil
+}
+
+// GetCentroid returns the centroid of the coordinates of the known nodes, me included
+func (cr *VivaldiCore[SUPPORT]) GetCentroid() ([]SUPPORT, error) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	return cr.centroid()
+}
+
+// GetDrift returns how far the centroid went from the origin, the height excluded
+func (cr *VivaldiCore[SUPPORT]) GetDrift() (float64, error) {
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	centroid, err := cr.centroid()
+	if err != nil {
+		return 0., err
+	}
+	return magnitude(centroid[:cr.positional()]), nil
+}
+
+// positional is the number of coordinates locating the node, the height is not one of them
+func (cr *VivaldiCore[SUPPORT]) positional() int {
+	if cr.space.Kind() == nvs.HeightVectorKind {
+		return cr.space.Dimension() - 1
+	}
+	return cr.space.Dimension()
+}
+
+// centroid averages my coordinates and the ones of the nodes that did not leave, the caller must hold the lock
+func (cr *VivaldiCore[SUPPORT]) centroid() ([]SUPPORT, error) {
+	points := []*nvs.Point[SUPPORT]{cr.myCoordinates}
+	for _, node := range cr.nodesCache {
+		if !node.HasLeft {
+			points = append(points, node.Coords)
+		}
+	}
+
+	centroid, err := cr.space.Centroid(points...)
+	if err != nil {
+		return nil, fmt.Errorf("error computing centroid, details: %s", err)
+	}
+
+	return centroid.GetCoordinates(), nil
+}
+
+// apply_gravity moves my coordinates toward the centroid, never beyond it. The height
+// is left alone, it models the access link and not the position. The caller must hold the lock
+func (cr *VivaldiCore[SUPPORT]) apply_gravity() {
+	if cr.gravity <= 0 {
+		return
+	}
+
+	centroid, err := cr.centroid()
+	if err != nil {
+		return
+	}
+
+	//The heights are set aside, they model the access links and not the positions
+	var zero SUPPORT
+	myPosition, centroidPosition := cr.myCoordinates.GetCoordinates(), append([]SUPPORT(nil), centroid...)
+	for i := cr.positional(); i < len(myPosition); i++ {
+		myPosition[i], centroidPosition[i] = zero, zero
+	}
+	myPoint, err := nvs.NewPoint(cr.space, myPosition)
+	if err != nil {
+		return
+	}
+	centroidPoint, err := nvs.NewPoint(cr.space, centroidPosition)
+	if err != nil {
+		return
+	}
+	distance, err := cr.space.Distance(myPoint, centroidPoint)
+	if err != nil || distance == 0 {
+		return
+	}
+
+	//My height replaces the one of the centroid, so that only the position is pulled
+	copy(centroid[cr.positional():], cr.myCoordinates.GetCoordinates()[cr.positional():])
+	centroidPoint, err = nvs.NewPoint(cr.space, centroid)
+	if err != nil {
+		return
+	}
+
+	pulled, err := cr.space.Lerp(cr.myCoordinates, centroidPoint, min(1., distance/(cr.gravity*cr.gravity)))
+	if err != nil {
+		return
+	}
+	cr.myCoordinates.SetCoordinates(pulled.GetCoordinates())
+}
+
+func magnitude[SUPPORT float64 | complex128](vector []SUPPORT) float64 {
+	sum := 0.
+	for _, v := range vector {
+		switch coord := any(v).(type) {
+		case float64:
+			sum += coord * coord
+		case complex128:
+			sum += real(coord)*real(coord) + imag(coord)*imag(coord)
+		}
+	}
+	return math.Sqrt(sum)
+}
+
+// SetClock replaces the time source of the core, useful to drive it in virtual time
+func (cr *VivaldiCore[SUPPORT]) SetClock(clock func() time.Time) {
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+	cr.clock = clock
 }
 
 func (cr *VivaldiCore[SUPPORT]) GetCoreSession() guid.Guid {
@@ -95,27 +510,46 @@ func (cr *VivaldiCore[SUPPORT]) GetKind() string {
 }
 
 func (cr *VivaldiCore[SUPPORT]) GetStateUpdates() (core.CoreData, error) {
-	cr.core_mu.RLock()
-	defer cr.core_mu.RUnlock()
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
 
 	retVal := &VivaldiMetadata[SUPPORT]{
 		Session:      cr.session,
 		Ej:           cr.ei,
 		Communicator: cr.myGUID,
+		SpaceKind:    cr.space.Kind(),
 	}
 
 	data := make(map[guid.Guid]VivaldiMetaCoor[SUPPORT])
 
 	data[cr.myGUID] = VivaldiMetaCoor[SUPPORT]{
-		IsFailed: false,
-		Coords:   cr.myCoordinates.GetCoordinates(),
+		IsFailed:    false,
+		Incarnation: cr.incarnation,
+		Coords:      cr.myCoordinates.GetCoordinates(),
+		Error:       cr.ei,
+		LastUpdate:  cr.lastUpdate,
 	}
 
+	now := cr.clock()
 	for k, v := range cr.nodesCache {
+		if v.HasLeft {
+			if now.Sub(v.LeftAt) > cr.tombstoneTTL {
+				delete(cr.nodesCache, k)
+				continue
+			}
+		} else if failed := cr.isFailed(k, v); failed != v.IsFailed {
+			v.IsFailed = failed
+			v.Updated = true
+		}
+
 		if v.Updated {
 			data[k] = VivaldiMetaCoor[SUPPORT]{
-				IsFailed: v.IsFailed,
-				Coords:   v.Coords.GetCoordinates(),
+				IsFailed:    v.IsFailed,
+				HasLeft:     v.HasLeft,
+				Incarnation: v.Incarnation,
+				Coords:      v.Coords.GetCoordinates(),
+				Error:       v.Error,
+				LastUpdate:  v.LastUpdate,
 			}
 
 			v.Updated = false
@@ -132,23 +566,12 @@ func (cr *VivaldiCore[SUPPORT]) updatePoint(point *nvs.Point[SUPPORT], newCoords
 	if err != nil {
 		return fmt.Errorf("error generating point updates, details: %s", err)
 	}
-	newCoordsPointHalf, err := cr.space.ExternalMul(newCoordsPoint, 0.5)
+	midpoint, err := cr.space.Lerp(point, newCoordsPoint, 0.5)
 	if err != nil {
 		return fmt.Errorf("error generating point updates, details: %s", err)
 	}
-	origPointHalf, err := cr.space.ExternalMul(point, 0.5)
-	if err != nil {
-		return fmt.Errorf("error generating point updates, details: %s", err)
-	}
-
-	newCoordsSliceHalf := newCoordsPointHalf.GetCoordinates()
-	origSliceHalf := origPointHalf.GetCoordinates()
-	sum := make([]SUPPORT, cr.space.Dimension())
-	for i := 0; i < cr.space.Dimension(); i++ {
-		sum[i] = newCoordsSliceHalf[i] + origSliceHalf[i]
-	}
 
-	res := point.SetCoordinates(sum)
+	res := point.SetCoordinates(midpoint.GetCoordinates())
 	if !res {
 		return errors.New("error setting coordinates for point, dimension/support not compatible")
 	}
@@ -159,6 +582,10 @@ func (cr *VivaldiCore[SUPPORT]) updatePoint(point *nvs.Point[SUPPORT], newCoords
 func (cr *VivaldiCore[SUPPORT]) vivaldi_update(rtt float64, ej float64, communicator guid.Guid) {
 
 
+	if rtt <= 0 {
+		return
+	}
+
 	var w float64
 	if cr.ei+ej != 0 {
 		w = cr.ei / (cr.ei + ej)
@@ -188,34 +615,25 @@ func (cr *VivaldiCore[SUPPORT]) vivaldi_update(rtt float64, ej float64, communic
 	delta := cr.cc * w
 
 
-	unit, err := cr.space.UnitVector(cr.myCoordinates, commCoords)
+	//The space decides how to move along the spring, additive steps only work in vector spaces
+	moved, err := cr.space.Step(cr.myCoordinates, commCoords, e*delta)
 	if err != nil {
 		return
 	}
+	newCoordinates := moved.GetCoordinates()
 
 
-	mulPt, err := cr.space.ExternalMul(unit, e*delta)
-	if err != nil {
-		return
-	}
-
-
-	newCoordinates := make([]SUPPORT, cr.space.Dimension())
-	myCoords := cr.myCoordinates.GetCoordinates()
-	mulPt_coors := mulPt.GetCoordinates()
-
-	for i := 0; i < cr.space.Dimension(); i++ {
-		newCoordinates[i] = myCoords[i] + mulPt_coors[i]
-	}
-
 	cr.myCoordinates.SetCoordinates(newCoordinates)
+	cr.apply_gravity()
+	cr.lastUpdate = cr.clock()
 
 }
 
 func (cr *VivaldiCore[SUPPORT]) GetMyState() (core.CoreData, error) {
 	cr.core_mu.RLock()
 	defer cr.core_mu.RUnlock()
-	return &VivaldiPeerState[SUPPORT]{Me: cr.myGUID, Coords: cr.myCoordinates.GetCoordinates(), Ej: cr.ei}, nil
+	return &VivaldiPeerState[SUPPORT]{Me: cr.myGUID, Coords: cr.myCoordinates.GetCoordinates(), Ej: cr.ei,
+		Incarnation: cr.incarnation, SpaceKind: cr.space.Kind(), LastUpdate: cr.lastUpdate}, nil
 }
 
 
@@ -238,18 +656,62 @@ func (cr *VivaldiCore[SUPPORT]) UpdateState(metadata core.CoreData) error {
 	var err error = nil
 	for extGuid, data := range nodes.Data {
 		if extGuid == cr.myGUID {
+			//SWIM-like refutation: a newer incarnation overrides any suspicion about us
+			if (data.IsFailed || data.HasLeft) && data.Incarnation >= cr.incarnation {
+				cr.incarnation = data.Incarnation + 1
+			}
 			continue
 		}
 		node, present := cr.nodesCache[extGuid]
 		if present {
-			node.IsFailed = data.IsFailed
-			if extGuid != nodes.Communicator {
-				if !node.Neighbor {
-					cr.updatePoint(node.Coords, data.Coords)
+			hadLeft := node.HasLeft
+			if extGuid == nodes.Communicator {
+				//Direct contact is a proof of life, whatever the incarnation we knew
+				node.IsFailed = false
+				if data.Incarnation > node.Incarnation {
+					node.HasLeft = data.HasLeft
+				} else {
+					node.HasLeft = node.HasLeft || data.HasLeft
 				}
+				node.Incarnation = max(node.Incarnation, data.Incarnation)
+			} else if data.Incarnation > node.Incarnation {
+				node.IsFailed = data.IsFailed
+				node.HasLeft = data.HasLeft
+				node.Incarnation = data.Incarnation
+				//The node refuted our suspicion, the gossiped status holds until it contacts us again
+				cr.detector.Forget(extGuid)
+			} else if data.Incarnation == node.Incarnation {
+				node.IsFailed = node.IsFailed || data.IsFailed
+				node.HasLeft = node.HasLeft || data.HasLeft
 			} else {
+				//Stale information, ignoring
+				continue
+			}
+
+			if node.HasLeft && !hadLeft {
+				node.LeftAt = cr.clock()
+				cr.detector.Forget(extGuid)
+				cr.forget_samples(extGuid)
+			}
+
+			//Direct contact carries the current state of the node, relayed information is merged
+			//unless older than the known one. Error and coordinates always come from the same one
+			if extGuid == nodes.Communicator || !data.LastUpdate.Before(node.LastUpdate) {
+				if !data.LastUpdate.IsZero() {
+					node.Error = data.Error
+					node.LastUpdate = data.LastUpdate
+				}
+				node.ReceivedAt = cr.clock()
+				if extGuid != nodes.Communicator {
+					if !node.Neighbor {
+						cr.updatePoint(node.Coords, data.Coords)
+					}
+				} else {
+					node.Coords.SetCoordinates(data.Coords)
+				}
+			}
+			if extGuid == nodes.Communicator {
 				node.Neighbor = true
-				node.Coords.SetCoordinates(data.Coords)
 			}
 			node.Updated = true
 		} else {
@@ -262,10 +724,21 @@ func (cr *VivaldiCore[SUPPORT]) UpdateState(metadata core.CoreData) error {
 			}
 
 			node = &nodeData[SUPPORT]{
-				IsFailed: data.IsFailed,
-				Updated:  true,
-				Coords:   point,
-				Neighbor: false,
+				IsFailed:    data.IsFailed,
+				Incarnation: data.Incarnation,
+				Updated:     true,
+				Coords:      point,
+				Neighbor:    false,
+				HasLeft:     data.HasLeft,
+				Error:       initialError,
+				ReceivedAt:  cr.clock(),
+			}
+			if !data.LastUpdate.IsZero() {
+				node.Error = data.Error
+				node.LastUpdate = data.LastUpdate
+			}
+			if node.HasLeft {
+				node.LeftAt = cr.clock()
 			}
 
 			if extGuid == nodes.Communicator {
@@ -276,7 +749,17 @@ func (cr *VivaldiCore[SUPPORT]) UpdateState(metadata core.CoreData) error {
 		}
 	}
 
-	cr.vivaldi_update(nodes.Rtt, nodes.Ej, nodes.Communicator)
+	if node, present := cr.nodesCache[nodes.Communicator]; present {
+		node.Error = nodes.Ej
+	}
+
+	cr.detector.Heartbeat(nodes.Communicator, cr.clock())
+
+	rtt := nodes.Rtt
+	if cr.filter != nil && rtt > 0 {
+		rtt = cr.filter.Filter(nodes.Communicator, rtt)
+	}
+	cr.vivaldi_update(rtt, nodes.Ej, nodes.Communicator)
 
 	//Classical Observer notify, the observers will keep a reference to the core to get the updates
 	//cr.PushToChannels(true)
@@ -284,6 +767,8 @@ func (cr *VivaldiCore[SUPPORT]) UpdateState(metadata core.CoreData) error {
 	return err
 }
 
+// SignalFailed marks as failed only the peers the failure detector knows nothing
+// about, the others are judged by their suspicion level
 func (cr *VivaldiCore[SUPPORT]) SignalFailed(peers []guid.Guid) {
 	cr.core_mu.Lock()
 	defer cr.core_mu.Unlock()
@@ -293,11 +778,51 @@ func (cr *VivaldiCore[SUPPORT]) SignalFailed(peers []guid.Guid) {
 		if !present {
 			continue
 		}
+		if _, known := cr.detector.Phi(peer, cr.clock()); known {
+			continue
+		}
 		data.IsFailed = true
 		data.Updated = true
 	}
 }
 
+// SignalLeft records that the peer left the session on purpose at the given
+// incarnation, the tombstone is gossiped so that the others stop waiting for it
+func (cr *VivaldiCore[SUPPORT]) SignalLeft(peer guid.Guid, incarnation uint64) {
+	cr.core_mu.Lock()
+	defer cr.core_mu.Unlock()
+
+	if peer == cr.myGUID {
+		return
+	}
+
+	node, present := cr.nodesCache[peer]
+	if !present {
+		//A tombstone for a node we never heard of still has to spread, its coordinates are never used
+		point, err := nvs.NewPoint(cr.space, make([]SUPPORT, cr.space.Dimension()))
+		if err != nil {
+			return
+		}
+		node = &nodeData[SUPPORT]{Coords: point, Error: initialError, Incarnation: incarnation}
+		cr.nodesCache[peer] = node
+	} else if incarnation < node.Incarnation || node.HasLeft && incarnation == node.Incarnation {
+		return
+	}
+
+	node.HasLeft = true
+	node.LeftAt = cr.clock()
+	node.Incarnation = incarnation
+	node.Updated = true
+	cr.detector.Forget(peer)
+	cr.forget_samples(peer)
+}
+
+func (cr *VivaldiCore[SUPPORT]) forget_samples(peer guid.Guid) {
+	if cr.filter != nil {
+		cr.filter.Forget(peer)
+	}
+}
+
 func NewVivaldiCore[SUPPORT float64 | complex128](myGuid guid.Guid, myCoords []SUPPORT, space *nvs.NormedVectorSpace[SUPPORT],
 	ce float64, cc float64) (*VivaldiCore[SUPPORT], error) {
 
@@ -308,6 +833,14 @@ func NewVivaldiCore[SUPPORT float64 | complex128](myGuid guid.Guid, myCoords []S
 	if err != nil {
 		return nil, errors.New("initial coordinate not compatible with the requested space")
 	}
+	//Maps the initial coordinates inside the space, e.g. the origin on a sphere
+	space_coords.SetCoordinates(myCoords)
+
+	detector, err := failuredetector.NewPhiAccrualDetector(failuredetector.DefaultWindowSize,
+		failuredetector.DefaultMinStdDeviation, failuredetector.DefaultFirstHeartbeatEstimate)
+	if err != nil {
+		return nil, fmt.Errorf("error creating failure detector, details: %s", err)
+	}
 
 	cr := &VivaldiCore[SUPPORT]{
 		nodesCache:    make(map[guid.Guid]*nodeData[SUPPORT]),
@@ -316,7 +849,13 @@ func NewVivaldiCore[SUPPORT float64 | complex128](myGuid guid.Guid, myCoords []S
 		space:         space,
 		ce:            ce,
 		cc:            cc,
-		ei:            10.,
+		ei:            initialError,
+		lastUpdate:    time.Now(),
+
+		detector:     detector,
+		phiThreshold: DefaultPhiThreshold,
+		clock:        time.Now,
+		tombstoneTTL: DefaultTombstoneTTL,
 
 		ChannelObserverSubjectImpl: channelobserver.NewChannelObserverSubjectImpl(),
 	}
@@ -325,8 +864,12 @@ func NewVivaldiCore[SUPPORT float64 | complex128](myGuid guid.Guid, myCoords []S
 }
 
 type VivaldiMetaCoor[SUPPORT float64 | complex128] struct {
-	IsFailed bool
-	Coords   []SUPPORT
+	IsFailed    bool
+	HasLeft     bool
+	Incarnation uint64
+	Coords      []SUPPORT
+	Error       float64
+	LastUpdate  time.Time
 }
 
 type VivaldiMetadata[SUPPORT float64 | complex128] struct {
@@ -336,12 +879,16 @@ type VivaldiMetadata[SUPPORT float64 | complex128] struct {
 	Rtt          float64
 	Ej           float64
 	Communicator guid.Guid
+	SpaceKind    string
 }
 
 type VivaldiPeerState[SUPPORT float64 | complex128] struct {
-	Me     guid.Guid
-	Coords []SUPPORT
-	Ej     float64
+	Me          guid.Guid
+	Coords      []SUPPORT
+	Ej          float64
+	Incarnation uint64
+	SpaceKind   string
+	LastUpdate  time.Time
 }
 
 //DUMP_PUSH
@@ -355,20 +902,28 @@ func (cr *VivaldiCore[SUPPORT]) DumpCore() (*VivaldiMetadata[SUPPORT], error) {
 		Session:      cr.session,
 		Ej:           cr.ei,
 		Communicator: cr.myGUID,
+		SpaceKind:    cr.space.Kind(),
 	}
 
 	data := make(map[guid.Guid]VivaldiMetaCoor[SUPPORT])
 
 	data[cr.myGUID] = VivaldiMetaCoor[SUPPORT]{
-		IsFailed: false,
-		Coords:   cr.myCoordinates.GetCoordinates(),
+		IsFailed:    false,
+		Incarnation: cr.incarnation,
+		Coords:      cr.myCoordinates.GetCoordinates(),
+		Error:       cr.ei,
+		LastUpdate:  cr.lastUpdate,
 	}
 
 	for k, v := range cr.nodesCache {
 
 		data[k] = VivaldiMetaCoor[SUPPORT]{
-			IsFailed: v.IsFailed,
-			Coords:   v.Coords.GetCoordinates(),
+			IsFailed:    v.IsFailed,
+			HasLeft:     v.HasLeft,
+			Incarnation: v.Incarnation,
+			Coords:      v.Coords.GetCoordinates(),
+			Error:       v.Error,
+			LastUpdate:  v.LastUpdate,
 		}
 	}
 
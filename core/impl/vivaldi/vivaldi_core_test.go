@@ -0,0 +1,501 @@
+package vivaldi
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func newTestCore(t *testing.T, me guid.Guid) *VivaldiCore[float64] {
+	space, err := nvs.NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	cr, err := NewVivaldiCore(me, []float64{0., 0.}, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+
+	return cr
+}
+
+func TestIncarnationRefutation(t *testing.T) {
+	me, other, third := guid.Guid{1}, guid.Guid{2}, guid.Guid{3}
+	cr := newTestCore(t, me)
+
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			me:    {IsFailed: true, Incarnation: 0, Coords: []float64{0., 0.}},
+			other: {Incarnation: 0, Coords: []float64{1., 1.}},
+			third: {Incarnation: 3, Coords: []float64{2., 2.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: other,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	state, _ := cr.GetMyState()
+	if state.(*VivaldiPeerState[float64]).Incarnation != 1 {
+		t.Fatalf("Core should have refuted its suspicion by increasing its incarnation")
+	}
+
+	//Older information about third must not override the newer one
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			other: {Incarnation: 0, Coords: []float64{1., 1.}},
+			third: {IsFailed: true, Incarnation: 2, Coords: []float64{2., 2.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: other,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+	if cr.GetIsFailed(third) {
+		t.Fatalf("Stale failure information has been applied")
+	}
+
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			other: {Incarnation: 0, Coords: []float64{1., 1.}},
+			third: {IsFailed: true, Incarnation: 3, Coords: []float64{2., 2.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: other,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+	if !cr.GetIsFailed(third) {
+		t.Fatalf("Failure information at the current incarnation should have been applied")
+	}
+}
+
+func TestSuspicionRefuted(t *testing.T) {
+	me, other, third := guid.Guid{1}, guid.Guid{2}, guid.Guid{3}
+	cr := newTestCore(t, me)
+
+	now := time.Now()
+	cr.SetClock(func() time.Time { return now })
+
+	//other contacts us once and then only reaches us through third
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			other: {Incarnation: 0, Coords: []float64{1., 1.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: other,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	now = now.Add(time.Hour)
+	if !cr.GetIsFailed(other) {
+		t.Fatalf("A silent node should be suspected")
+	}
+	updates, _ := cr.GetStateUpdates()
+	if !updates.(*VivaldiMetadata[float64]).Data[other].IsFailed {
+		t.Fatalf("The suspicion should be gossiped")
+	}
+
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			third: {Incarnation: 0, Coords: []float64{2., 2.}},
+			other: {Incarnation: 1, Coords: []float64{1., 1.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: third,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+	if cr.GetIsFailed(other) {
+		t.Fatalf("The refutation should clear the suspicion")
+	}
+
+	updates, _ = cr.GetStateUpdates()
+	if data, ok := updates.(*VivaldiMetadata[float64]).Data[other]; ok && data.IsFailed {
+		t.Fatalf("The refuted node has been suspected again at its new incarnation")
+	}
+	if cr.GetIsFailed(other) {
+		t.Fatalf("The refuted node has been suspected again at its new incarnation")
+	}
+}
+
+func TestLeftTombstone(t *testing.T) {
+	me, other := guid.Guid{1}, guid.Guid{2}
+	cr := newTestCore(t, me)
+
+	now := time.Now()
+	cr.SetClock(func() time.Time { return now })
+
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			other: {Incarnation: 2, Coords: []float64{1., 1.}},
+		},
+		Rtt: 1., Ej: 1., Communicator: other,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	cr.SignalLeft(other, 1)
+	if cr.GetHasLeft(other) {
+		t.Fatalf("Leave at a stale incarnation has been applied")
+	}
+
+	cr.SignalLeft(other, 2)
+	if !cr.GetHasLeft(other) {
+		t.Fatalf("Leave at the current incarnation should have been applied")
+	}
+	if closest, _ := cr.GetClosestOf([]guid.Guid{other}); len(closest) != 0 {
+		t.Fatalf("A node that left should not be a candidate")
+	}
+
+	updates, _ := cr.GetStateUpdates()
+	if !updates.(*VivaldiMetadata[float64]).Data[other].HasLeft {
+		t.Fatalf("The tombstone should be gossiped")
+	}
+
+	now = now.Add(DefaultTombstoneTTL + time.Second)
+	updates, _ = cr.GetStateUpdates()
+	if _, ok := updates.(*VivaldiMetadata[float64]).Data[other]; ok {
+		t.Fatalf("Expired tombstone still gossiped")
+	}
+	if _, known := cr.GetIncarnation(other); known {
+		t.Fatalf("Expired tombstone still in the cache")
+	}
+}
+
+func TestRankedQueries(t *testing.T) {
+	me, communicator := guid.Guid{1}, guid.Guid{9}
+	near, middle, far, failed := guid.Guid{2}, guid.Guid{3}, guid.Guid{4}, guid.Guid{5}
+	cr := newTestCore(t, me)
+
+	//A zero rtt leaves the coordinates of the core in the origin
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			communicator: {Coords: []float64{30., 40.}},
+			near:         {Coords: []float64{3., 4.}},
+			middle:       {Coords: []float64{6., 8.}},
+			far:          {Coords: []float64{9., 12.}},
+			failed:       {IsFailed: true, Coords: []float64{0., 1.}},
+		},
+		Rtt: 0., Ej: 1., Communicator: communicator,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	candidates := []guid.Guid{far, failed, middle, near, guid.Guid{42}}
+
+	nearest, err := cr.GetKNearest(candidates, 3, core.QueryOptions{})
+	if err != nil {
+		t.Fatalf("Unable to query, details: %s", err)
+	}
+	if len(nearest) != 3 || nearest[0].Guid != failed || nearest[1].Guid != near || nearest[2].Guid != middle {
+		t.Fatalf("Wrong nearest nodes: %v", nearest)
+	}
+	if nearest[1].RTT != 5. {
+		t.Fatalf("Wrong estimated RTT %f, 5 expected", nearest[1].RTT)
+	}
+
+	nearest, _ = cr.GetKNearest(candidates, 3, core.QueryOptions{ExcludeFailed: true})
+	if len(nearest) != 3 || nearest[0].Guid != near || nearest[2].Guid != far {
+		t.Fatalf("Failed nodes should have been excluded: %v", nearest)
+	}
+
+	within, _ := cr.GetWithinRadius(nil, 10., core.QueryOptions{ExcludeFailed: true})
+	if len(within) != 2 || within[0].Guid != near || within[1].Guid != middle {
+		t.Fatalf("Wrong nodes within radius: %v", within)
+	}
+
+	sorted, _ := cr.GetSortedByDistance(nil, core.QueryOptions{})
+	if len(sorted) != 5 || sorted[4].Guid != communicator {
+		t.Fatalf("Every known node should be sorted: %v", sorted)
+	}
+}
+
+func TestEstimateRTT(t *testing.T) {
+	me, a, b := guid.Guid{1}, guid.Guid{2}, guid.Guid{3}
+	cr := newTestCore(t, me)
+
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			a: {Coords: []float64{3., 0.}},
+			b: {Coords: []float64{0., 4.}},
+		},
+		Rtt: 0., Ej: 0.2, Communicator: a,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	estimate, err := cr.EstimateRTT(a, b)
+	if err != nil {
+		t.Fatalf("Unable to estimate, details: %s", err)
+	}
+	if estimate.RTT != 5. {
+		t.Fatalf("Wrong RTT %f, 5 expected", estimate.RTT)
+	}
+	//b never communicated with us, its error is still the initial one
+	if estimate.Confidence != 0. {
+		t.Fatalf("Wrong confidence %f, 0 expected", estimate.Confidence)
+	}
+
+	if _, err := cr.EstimateRTT(a, guid.Guid{42}); err == nil {
+		t.Fatalf("Estimate towards an unknown node should fail")
+	}
+
+	cr.ei = 0.4
+	matrix, err := cr.EstimateRTTMatrix([]guid.Guid{me, a, b})
+	if err != nil {
+		t.Fatalf("Unable to estimate, details: %s", err)
+	}
+	if matrix[0][1].RTT != 3. || matrix[1][0] != matrix[0][1] || matrix[2][0].RTT != 4. || matrix[1][1].RTT != 0. {
+		t.Fatalf("Wrong matrix %v", matrix)
+	}
+	if math.Abs(matrix[0][1].Confidence-0.7) > 1e-9 {
+		t.Fatalf("Wrong confidence %f, 0.7 expected", matrix[0][1].Confidence)
+	}
+}
+
+func TestErrorPropagation(t *testing.T) {
+	me, communicator, converged, fresh := guid.Guid{1}, guid.Guid{2}, guid.Guid{3}, guid.Guid{4}
+	cr := newTestCore(t, me)
+
+	now := time.Now()
+	cr.SetClock(func() time.Time { return now })
+
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			communicator: {Coords: []float64{1., 0.}, Error: 0.1, LastUpdate: now},
+			converged:    {Coords: []float64{2., 0.}, Error: 0.05, LastUpdate: now.Add(-time.Second)},
+			fresh:        {Coords: []float64{3., 0.}, Error: 3., LastUpdate: now.Add(-time.Hour)},
+		},
+		Rtt: 0., Ej: 0.1, Communicator: communicator,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	updates, _ := cr.GetStateUpdates()
+	if data := updates.(*VivaldiMetadata[float64]).Data[converged]; data.Error != 0.05 || !data.LastUpdate.Equal(now.Add(-time.Second)) {
+		t.Fatalf("Error estimate not propagated: %v", data)
+	}
+
+	//Older information must override neither the error estimate nor the coordinates
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			communicator: {Coords: []float64{1., 0.}, Error: 0.1, LastUpdate: now},
+			converged:    {Coords: []float64{50., 0.}, Error: 5., LastUpdate: now.Add(-time.Minute)},
+		},
+		Rtt: 0., Ej: 0.1, Communicator: communicator,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	sorted, _ := cr.GetSortedByDistance(nil, core.QueryOptions{MaxError: 1.})
+	if len(sorted) != 2 || sorted[1].Guid != converged || sorted[1].Error != 0.05 || sorted[1].RTT != 2. {
+		t.Fatalf("Uncertain coordinates should have been filtered: %v", sorted)
+	}
+
+	//The age is measured on the local clock, whatever the clocks of the nodes say
+	now = now.Add(time.Hour)
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			communicator: {Coords: []float64{1., 0.}, Error: 0.1, LastUpdate: now.Add(-2 * time.Hour)},
+			converged:    {Coords: []float64{2., 0.}, Error: 0.05, LastUpdate: now.Add(-time.Hour)},
+		},
+		Rtt: 0., Ej: 0.1, Communicator: communicator,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	sorted, _ = cr.GetSortedByDistance(nil, core.QueryOptions{MaxAge: time.Minute})
+	if len(sorted) != 2 || sorted[0].Guid != communicator || sorted[1].Guid != converged {
+		t.Fatalf("Stale coordinates should have been filtered: %v", sorted)
+	}
+}
+
+// constantFilter replaces every sample with rtt
+type constantFilter struct {
+	rtt     float64
+	samples []float64
+}
+
+func (cf *constantFilter) Filter(_ guid.Guid, rtt float64) float64 {
+	cf.samples = append(cf.samples, rtt)
+	return cf.rtt
+}
+
+func (cf *constantFilter) Forget(guid.Guid) {}
+
+func TestLatencyFilter(t *testing.T) {
+	me, other := guid.Guid{1}, guid.Guid{2}
+	cr := newTestCore(t, me)
+
+	//The filtered rtt matches the distance, so the coordinates should not move
+	filter := &constantFilter{rtt: 5.}
+	cr.SetLatencyFilter(filter)
+
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			other: {Coords: []float64{3., 4.}},
+		},
+		Rtt: 500., Ej: 1., Communicator: other,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	if len(filter.samples) != 1 || filter.samples[0] != 500. {
+		t.Fatalf("The raw sample should go through the filter, got %v", filter.samples)
+	}
+	state, _ := cr.GetMyState()
+	if coords := state.(*VivaldiPeerState[float64]).Coords; coords[0] != 0. || coords[1] != 0. {
+		t.Fatalf("The raw sample moved the coordinates to %v", coords)
+	}
+}
+
+func TestGravity(t *testing.T) {
+	me, a, b := guid.Guid{1}, guid.Guid{2}, guid.Guid{3}
+	space, _ := nvs.NewRealEuclideanSpace(2)
+	cr, err := NewVivaldiCore(me, []float64{90., 0.}, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	if err := cr.SetGravity(10.); err != nil {
+		t.Fatalf("Unable to set gravity, details: %s", err)
+	}
+
+	//The rtt matches the distance, only gravity moves the coordinates
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			a: {Coords: []float64{0., 0.}},
+			b: {Coords: []float64{0., 0.}},
+		},
+		Rtt: 90., Ej: 1., Communicator: a,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	//The centroid is at 30, 60 away with strength 60/10^2
+	state, _ := cr.GetMyState()
+	if coords := state.(*VivaldiPeerState[float64]).Coords; math.Abs(coords[0]-54.) > 1e-9 || coords[1] != 0. {
+		t.Fatalf("Wrong coordinates %v, [54 0] expected", coords)
+	}
+
+	drift, err := cr.GetDrift()
+	if err != nil || math.Abs(drift-18.) > 1e-9 {
+		t.Fatalf("Wrong drift %v, 18 expected", drift)
+	}
+}
+
+func TestGravityDistance(t *testing.T) {
+	me, a, b := guid.Guid{1}, guid.Guid{2}, guid.Guid{3}
+	space, _ := nvs.NewMinkowskiSpace(2, 1.)
+	cr, err := NewVivaldiCore(me, []float64{60., 60.}, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to create core, details: %s", err)
+	}
+	if err := cr.SetGravity(10.); err != nil {
+		t.Fatalf("Unable to set gravity, details: %s", err)
+	}
+
+	err = cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			a: {Coords: []float64{0., 0.}},
+			b: {Coords: []float64{0., 0.}},
+		},
+		Rtt: 120., Ej: 1., Communicator: a,
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	//The centroid is at 20, 20: 80 away in the manhattan distance, with strength 80/10^2
+	state, _ := cr.GetMyState()
+	if coords := state.(*VivaldiPeerState[float64]).Coords; math.Abs(coords[0]-28.) > 1e-9 || math.Abs(coords[1]-28.) > 1e-9 {
+		t.Fatalf("Wrong coordinates %v, [28 28] expected", coords)
+	}
+}
+
+// checkConvergence lets two cores of space measure rtt between them and checks their estimate
+func TestGravityCurvedSpaces(t *testing.T) {
+	hyperbolic, _ := nvs.NewHyperbolicSpace(2, 1e-4)
+	spherical, _ := nvs.NewSphericalSpace(2, nvs.SphereRadiusForMaxRTT(300.))
+
+	for _, space := range []*nvs.NormedVectorSpace[float64]{hyperbolic, spherical} {
+		cr, err := NewVivaldiCore(guid.Guid{1}, make([]float64, space.Dimension()), space, 0.25, 0.25)
+		if err != nil {
+			t.Fatalf("Unable to create core, details: %s", err)
+		}
+		if err = cr.SetGravity(10.); err == nil {
+			t.Fatalf("Expected gravity to be refused in the %s space", space.Kind())
+		}
+		if err = cr.SetGravity(0.); err != nil {
+			t.Fatalf("Disabling gravity should always succeed, details: %s", err)
+		}
+	}
+}
+
+func checkConvergence(t *testing.T, space *nvs.NormedVectorSpace[float64], rtt float64) {
+	first, second := guid.Guid{1}, guid.Guid{2}
+
+	cores := make(map[guid.Guid]*VivaldiCore[float64])
+	for _, me := range []guid.Guid{first, second} {
+		cr, err := NewVivaldiCore(me, make([]float64, space.Dimension()), space, 0.25, 0.25)
+		if err != nil {
+			t.Fatalf("Unable to create core, details: %s", err)
+		}
+		cores[me] = cr
+	}
+
+	for i := 0; i < 200; i++ {
+		for me, other := range map[guid.Guid]guid.Guid{first: second, second: first} {
+			state, _ := cores[other].GetMyState()
+			otherState := state.(*VivaldiPeerState[float64])
+			err := cores[me].UpdateState(&VivaldiMetadata[float64]{
+				Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+					other: {Coords: otherState.Coords},
+				},
+				Rtt: rtt, Ej: otherState.Ej, Communicator: other,
+			})
+			if err != nil {
+				t.Fatalf("Unable to update state, details: %s", err)
+			}
+		}
+	}
+
+	estimate, err := cores[first].EstimateRTT(first, second)
+	if err != nil || math.Abs(estimate.RTT-rtt) > 1. {
+		t.Fatalf("Cores did not converge in the %s space, estimated RTT %v", space.Kind(), estimate.RTT)
+	}
+}
+
+func TestHyperbolicConvergence(t *testing.T) {
+	space, _ := nvs.NewHyperbolicSpace(2, 1e-4)
+	checkConvergence(t, space, 50.)
+}
+
+func TestSphericalConvergence(t *testing.T) {
+	space, _ := nvs.NewSphericalSpace(2, nvs.SphereRadiusForMaxRTT(300.))
+	checkConvergence(t, space, 120.)
+}
+
+func TestMinkowskiConvergence(t *testing.T) {
+	manhattan, _ := nvs.NewMinkowskiSpace(2, 1.)
+	chebyshev, _ := nvs.NewMinkowskiSpace(2, math.Inf(1))
+	weighted, _ := nvs.NewWeightedMinkowskiSpace(2, 3., []float64{1., 0.5})
+
+	for _, space := range []*nvs.NormedVectorSpace[float64]{manhattan, chebyshev, weighted} {
+		checkConvergence(t, space, 120.)
+	}
+}
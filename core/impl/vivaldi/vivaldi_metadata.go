@@ -0,0 +1,35 @@
+package vivaldi
+
+import "github.com/sebastianopriscan/GNCFD/utils/guid"
+
+// CloneMetadata deep copies the metadata, as a serialization would, so that in-process
+// transports never let cores share coordinate slices, and stamps the copy with rtt
+func CloneMetadata[SUPPORT float64 | complex128](nodes *VivaldiMetadata[SUPPORT], rtt float64) *VivaldiMetadata[SUPPORT] {
+	retVal := *nodes
+	retVal.Rtt = rtt
+	retVal.Data = make(map[guid.Guid]VivaldiMetaCoor[SUPPORT], len(nodes.Data))
+
+	for k, v := range nodes.Data {
+		v.Coords = append(make([]SUPPORT, 0, len(v.Coords)), v.Coords...)
+		retVal.Data[k] = v
+	}
+
+	return &retVal
+}
+
+// ForwardedMetadata is a copy of nodes relayed by the node in state, which adds its
+// own coordinates and becomes the communicator, as the gRPC Forward does
+func ForwardedMetadata[SUPPORT float64 | complex128](nodes *VivaldiMetadata[SUPPORT], state *VivaldiPeerState[SUPPORT]) *VivaldiMetadata[SUPPORT] {
+	retVal := CloneMetadata(nodes, 0.)
+
+	retVal.Data[state.Me] = VivaldiMetaCoor[SUPPORT]{
+		Incarnation: state.Incarnation,
+		Coords:      append(make([]SUPPORT, 0, len(state.Coords)), state.Coords...),
+		Error:       state.Ej,
+		LastUpdate:  state.LastUpdate,
+	}
+	retVal.Communicator = state.Me
+	retVal.Ej = state.Ej
+
+	return retVal
+}
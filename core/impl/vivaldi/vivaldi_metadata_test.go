@@ -0,0 +1,37 @@
+package vivaldi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func TestForwardedMetadata(t *testing.T) {
+	origin, forwarder := guid.Guid{1}, guid.Guid{2}
+	nodes := &VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			origin: {Coords: []float64{1., 1.}},
+		},
+		Rtt: 10., Ej: 0.5, Communicator: origin,
+	}
+	state := &VivaldiPeerState[float64]{
+		Me: forwarder, Coords: []float64{2., 2.}, Ej: 0.3, Incarnation: 4,
+		LastUpdate: time.Unix(1000, 0),
+	}
+
+	forwarded := ForwardedMetadata(nodes, state)
+
+	if forwarded.Communicator != forwarder || forwarded.Ej != 0.3 || forwarded.Rtt != 0. {
+		t.Fatalf("The forwarder should be the communicator, got %+v", forwarded)
+	}
+	entry, ok := forwarded.Data[forwarder]
+	if !ok || entry.Incarnation != 4 || entry.Error != 0.3 || !entry.LastUpdate.Equal(state.LastUpdate) {
+		t.Fatalf("Wrong forwarder entry %+v", entry)
+	}
+
+	forwarded.Data[origin].Coords[0] = 100.
+	if len(nodes.Data) != 1 || nodes.Data[origin].Coords[0] != 1. || nodes.Communicator != origin {
+		t.Fatalf("The forwarded copy shares state with the received metadata")
+	}
+}
@@ -0,0 +1,223 @@
+package vivaldi
+
+import (
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// SnapshotVersion is bumped at every incompatible change of VivaldiSnapshot
+const SnapshotVersion = 1
+
+// VivaldiSnapshot is the persistent state of a core, enough to restart it
+// without converging again from the origin
+type VivaldiSnapshot[SUPPORT float64 | complex128] struct {
+	Version int
+
+	Me          guid.Guid
+	Session     guid.Guid
+	SpaceKind   string
+	Coords      []SUPPORT
+	Error       float64
+	Incarnation uint64
+	LastUpdate  time.Time
+
+	Nodes map[guid.Guid]SnapshotNode[SUPPORT]
+}
+
+type SnapshotNode[SUPPORT float64 | complex128] struct {
+	IsFailed    bool
+	HasLeft     bool
+	LeftAt      time.Time
+	Incarnation uint64
+	Neighbor    bool
+	Coords      []SUPPORT
+	Error       float64
+	LastUpdate  time.Time
+	ReceivedAt  time.Time
+}
+
+func (cr *VivaldiCore[SUPPORT]) Snapshot() *VivaldiSnapshot[SUPPORT] {
+	session := cr.GetCoreSession()
+
+	cr.core_mu.RLock()
+	defer cr.core_mu.RUnlock()
+
+	retVal := &VivaldiSnapshot[SUPPORT]{
+		Version:     SnapshotVersion,
+		Me:          cr.myGUID,
+		Session:     session,
+		SpaceKind:   cr.space.Kind(),
+		Coords:      cr.myCoordinates.GetCoordinates(),
+		Error:       cr.ei,
+		Incarnation: cr.incarnation,
+		LastUpdate:  cr.lastUpdate,
+		Nodes:       make(map[guid.Guid]SnapshotNode[SUPPORT], len(cr.nodesCache)),
+	}
+
+	for k, v := range cr.nodesCache {
+		retVal.Nodes[k] = SnapshotNode[SUPPORT]{
+			IsFailed:    v.IsFailed,
+			HasLeft:     v.HasLeft,
+			LeftAt:      v.LeftAt,
+			Incarnation: v.Incarnation,
+			Neighbor:    v.Neighbor,
+			Coords:      v.Coords.GetCoordinates(),
+			Error:       v.Error,
+			LastUpdate:  v.LastUpdate,
+			ReceivedAt:  v.ReceivedAt,
+		}
+	}
+
+	return retVal
+}
+
+func (cr *VivaldiCore[SUPPORT]) WriteSnapshot(w io.Writer) error {
+	if err := gob.NewEncoder(w).Encode(cr.Snapshot()); err != nil {
+		return fmt.Errorf("error encoding snapshot, details: %s", err)
+	}
+	return nil
+}
+
+// SaveSnapshot writes the snapshot next to path and renames it, so that a crash
+// never leaves a truncated snapshot behind
+func (cr *VivaldiCore[SUPPORT]) SaveSnapshot(path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("error creating snapshot file, details: %s", err)
+	}
+	defer os.Remove(file.Name())
+
+	if err = cr.WriteSnapshot(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot file, details: %s", err)
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("error replacing snapshot file, details: %s", err)
+	}
+
+	return nil
+}
+
+func ReadSnapshot[SUPPORT float64 | complex128](r io.Reader) (*VivaldiSnapshot[SUPPORT], error) {
+	snapshot := &VivaldiSnapshot[SUPPORT]{}
+	if err := gob.NewDecoder(r).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding snapshot, details: %s", err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, fmt.Errorf("error: snapshot version %d, %d supported", snapshot.Version, SnapshotVersion)
+	}
+
+	return snapshot, nil
+}
+
+func LoadSnapshot[SUPPORT float64 | complex128](path string) (*VivaldiSnapshot[SUPPORT], error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening snapshot file, details: %s", err)
+	}
+	defer file.Close()
+
+	return ReadSnapshot[SUPPORT](file)
+}
+
+// RestoreVivaldiCore creates a core starting from snapshot. The incarnation is
+// increased, since the others may have suspected the node while it was down.
+// Only the kind and the dimension of space are checked, its parameters (curvature,
+// radius, exponent, weights) must be the ones of the space the snapshot was taken in
+func RestoreVivaldiCore[SUPPORT float64 | complex128](snapshot *VivaldiSnapshot[SUPPORT], space *nvs.NormedVectorSpace[SUPPORT],
+	ce float64, cc float64) (*VivaldiCore[SUPPORT], error) {
+
+	if snapshot.SpaceKind != space.Kind() {
+		return nil, fmt.Errorf("error: snapshot of a %s space, %s given", snapshot.SpaceKind, space.Kind())
+	}
+
+	cr, err := NewVivaldiCore(snapshot.Me, snapshot.Coords, space, ce, cc)
+	if err != nil {
+		return nil, fmt.Errorf("error restoring own coordinates, details: %s", err)
+	}
+	cr.SetCoreSession(snapshot.Session)
+
+	cr.ei = snapshot.Error
+	cr.incarnation = snapshot.Incarnation + 1
+	cr.lastUpdate = snapshot.LastUpdate
+
+	for k, v := range snapshot.Nodes {
+		point, err := nvs.NewPoint(space, v.Coords)
+		if err != nil {
+			return nil, fmt.Errorf("error restoring coordinates of %v, details: %s", k, err)
+		}
+		cr.nodesCache[k] = &nodeData[SUPPORT]{
+			IsFailed:    v.IsFailed,
+			HasLeft:     v.HasLeft,
+			LeftAt:      v.LeftAt,
+			Incarnation: v.Incarnation,
+			Neighbor:    v.Neighbor,
+			Coords:      point,
+			Error:       v.Error,
+			LastUpdate:  v.LastUpdate,
+			ReceivedAt:  v.ReceivedAt,
+		}
+	}
+
+	return cr, nil
+}
+
+// StartCheckpointing saves a snapshot to path every interval until StopCheckpointing is called
+func (cr *VivaldiCore[SUPPORT]) StartCheckpointing(path string, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("the checkpoint interval should be greater than 0")
+	}
+
+	cr.checkpoint_mu.Lock()
+	defer cr.checkpoint_mu.Unlock()
+
+	if cr.checkpointchann != nil {
+		return errors.New("error: checkpointing already started")
+	}
+
+	cr.checkpointchann = make(chan bool)
+	go cr.checkpoint_routine(path, interval, cr.checkpointchann)
+
+	return nil
+}
+
+func (cr *VivaldiCore[SUPPORT]) StopCheckpointing() {
+	cr.checkpoint_mu.Lock()
+	defer cr.checkpoint_mu.Unlock()
+
+	if cr.checkpointchann == nil {
+		return
+	}
+
+	cr.checkpointchann <- true
+	close(cr.checkpointchann)
+	cr.checkpointchann = nil
+}
+
+func (cr *VivaldiCore[SUPPORT]) checkpoint_routine(path string, interval time.Duration, stopchann chan bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopchann:
+			return
+		case <-ticker.C:
+			if err := cr.SaveSnapshot(path); err != nil {
+				log.Printf("error in checkpoint, details: %s\n", err)
+			}
+		}
+	}
+}
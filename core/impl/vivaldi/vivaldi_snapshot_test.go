@@ -0,0 +1,85 @@
+package vivaldi
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	me, other := guid.Guid{1}, guid.Guid{2}
+	cr := newTestCore(t, me)
+	cr.SetCoreSession(guid.Guid{7})
+
+	err := cr.UpdateState(&VivaldiMetadata[float64]{
+		Data: map[guid.Guid]VivaldiMetaCoor[float64]{
+			other: {Incarnation: 3, Coords: []float64{3., 4.}, Error: 0.2, LastUpdate: time.Now()},
+		},
+		Rtt: 10., Ej: 0.2, Communicator: other, Session: guid.Guid{7},
+	})
+	if err != nil {
+		t.Fatalf("Unable to update state, details: %s", err)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := cr.WriteSnapshot(buffer); err != nil {
+		t.Fatalf("Unable to write snapshot, details: %s", err)
+	}
+	snapshot, err := ReadSnapshot[float64](buffer)
+	if err != nil {
+		t.Fatalf("Unable to read snapshot, details: %s", err)
+	}
+
+	space, _ := nvs.NewRealEuclideanSpace(2)
+	restored, err := RestoreVivaldiCore(snapshot, space, 0.25, 0.25)
+	if err != nil {
+		t.Fatalf("Unable to restore core, details: %s", err)
+	}
+
+	if restored.GetCoreSession() != (guid.Guid{7}) || restored.ei != cr.ei || restored.incarnation != cr.incarnation+1 {
+		t.Fatalf("Own state not restored")
+	}
+	before, _ := cr.EstimateRTT(me, other)
+	after, err := restored.EstimateRTT(me, other)
+	if err != nil || before != after {
+		t.Fatalf("Neighbour cache not restored, %v instead of %v", after, before)
+	}
+	if incarnation, _ := restored.GetIncarnation(other); incarnation != 3 {
+		t.Fatalf("Wrong incarnation %d restored, 3 expected", incarnation)
+	}
+
+	heightSpace, _ := nvs.NewHeightVectorSpace(1)
+	if _, err := RestoreVivaldiCore(snapshot, heightSpace, 0.25, 0.25); err == nil {
+		t.Fatalf("Restore on a different space should fail")
+	}
+	largerSpace, _ := nvs.NewRealEuclideanSpace(3)
+	if _, err := RestoreVivaldiCore(snapshot, largerSpace, 0.25, 0.25); err == nil {
+		t.Fatalf("Restore on a space of different dimension should fail")
+	}
+}
+
+func TestCheckpointing(t *testing.T) {
+	cr := newTestCore(t, guid.Guid{1})
+	path := filepath.Join(t.TempDir(), "core.snapshot")
+
+	if err := cr.StartCheckpointing(path, 10*time.Millisecond); err != nil {
+		t.Fatalf("Unable to start checkpointing, details: %s", err)
+	}
+	if err := cr.StartCheckpointing(path, 10*time.Millisecond); err == nil {
+		t.Fatalf("Checkpointing started twice")
+	}
+	time.Sleep(50 * time.Millisecond)
+	cr.StopCheckpointing()
+
+	snapshot, err := LoadSnapshot[float64](path)
+	if err != nil {
+		t.Fatalf("Unable to load checkpoint, details: %s", err)
+	}
+	if snapshot.Me != (guid.Guid{1}) {
+		t.Fatalf("Wrong checkpoint content")
+	}
+}
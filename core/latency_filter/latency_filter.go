@@ -0,0 +1,147 @@
+package latencyfilter
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// The defaults are the moving percentile filter by Ledlie et al., which keeps
+// the coordinates stable in spite of the latency spikes of real networks
+const (
+	DefaultWindowSize = 4
+	DefaultPercentile = 25.
+	DefaultAlpha      = 0.2
+)
+
+// LatencyFilter turns the raw RTT samples measured towards a peer into the
+// RTT fed to the coordinate update
+type LatencyFilter interface {
+	Filter(peer guid.Guid, rtt float64) float64
+	// Forget drops the history of peer
+	Forget(peer guid.Guid)
+}
+
+type sampleWindow struct {
+	samples []float64
+	next    int
+	full    bool
+}
+
+func (sw *sampleWindow) add(sample float64) {
+	sw.samples[sw.next] = sample
+	sw.next = (sw.next + 1) % len(sw.samples)
+	if sw.next == 0 {
+		sw.full = true
+	}
+}
+
+func (sw *sampleWindow) values() []float64 {
+	if sw.full {
+		return append(make([]float64, 0, len(sw.samples)), sw.samples...)
+	}
+	return append(make([]float64, 0, sw.next), sw.samples[:sw.next]...)
+}
+
+// MovingPercentileFilter outputs a percentile of the last samples of every peer
+type MovingPercentileFilter struct {
+	mu sync.Mutex
+
+	windowSize int
+	percentile float64
+
+	windows map[guid.Guid]*sampleWindow
+}
+
+// NewMovingPercentileFilter keeps windowSize samples per peer, percentile goes from 0 to 100
+func NewMovingPercentileFilter(windowSize int, percentile float64) (*MovingPercentileFilter, error) {
+	if windowSize <= 0 {
+		return nil, errors.New("window size should be greater than 0")
+	}
+	if percentile < 0 || percentile > 100 {
+		return nil, errors.New("percentile should be between 0 and 100")
+	}
+
+	return &MovingPercentileFilter{
+		windowSize: windowSize,
+		percentile: percentile,
+		windows:    make(map[guid.Guid]*sampleWindow),
+	}, nil
+}
+
+// NewMedianFilter outputs the median of the last windowSize samples of every peer
+func NewMedianFilter(windowSize int) (*MovingPercentileFilter, error) {
+	return NewMovingPercentileFilter(windowSize, 50.)
+}
+
+func (mp *MovingPercentileFilter) Filter(peer guid.Guid, rtt float64) float64 {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	window, present := mp.windows[peer]
+	if !present {
+		window = &sampleWindow{samples: make([]float64, mp.windowSize)}
+		mp.windows[peer] = window
+	}
+	window.add(rtt)
+
+	values := window.values()
+	sort.Float64s(values)
+
+	//Nearest rank, so that the output is always a measured sample
+	rank := int(math.Ceil(mp.percentile / 100. * float64(len(values))))
+	return values[max(0, min(rank-1, len(values)-1))]
+}
+
+func (mp *MovingPercentileFilter) Forget(peer guid.Guid) {
+	mp.mu.Lock()
+	defer mp.mu.Unlock()
+
+	delete(mp.windows, peer)
+}
+
+// EWMAFilter outputs the exponentially weighted moving average of the samples of every peer
+type EWMAFilter struct {
+	mu sync.Mutex
+
+	alpha float64
+
+	averages map[guid.Guid]float64
+}
+
+// NewEWMAFilter weights every new sample alpha, which goes from 0 excluded to 1
+func NewEWMAFilter(alpha float64) (*EWMAFilter, error) {
+	if alpha <= 0 || alpha > 1 {
+		return nil, errors.New("alpha should be in (0, 1]")
+	}
+
+	return &EWMAFilter{
+		alpha:    alpha,
+		averages: make(map[guid.Guid]float64),
+	}, nil
+}
+
+func (ef *EWMAFilter) Filter(peer guid.Guid, rtt float64) float64 {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+
+	average, present := ef.averages[peer]
+	if !present {
+		average = rtt
+	} else {
+		average = ef.alpha*rtt + (1-ef.alpha)*average
+	}
+	ef.averages[peer] = average
+
+	return average
+}
+
+func (ef *EWMAFilter) Forget(peer guid.Guid) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+
+	delete(ef.averages, peer)
+}
@@ -0,0 +1,70 @@
+package latencyfilter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func TestPercentileFilterIgnoresSpikes(t *testing.T) {
+	filter, err := NewMovingPercentileFilter(DefaultWindowSize, DefaultPercentile)
+	if err != nil {
+		t.Fatalf("Unable to create filter, details: %s", err)
+	}
+
+	peer, other := guid.Guid{1}, guid.Guid{2}
+	for _, rtt := range []float64{10., 11., 12.} {
+		filter.Filter(peer, rtt)
+	}
+	if filtered := filter.Filter(peer, 500.); filtered != 10. {
+		t.Fatalf("Spike not filtered, got %v", filtered)
+	}
+	if filtered := filter.Filter(other, 40.); filtered != 40. {
+		t.Fatalf("History should be kept per peer, got %v", filtered)
+	}
+
+	//The window slides, the oldest samples are replaced
+	for _, rtt := range []float64{30., 31., 32.} {
+		filter.Filter(peer, rtt)
+	}
+	if filtered := filter.Filter(peer, 33.); filtered != 30. {
+		t.Fatalf("Old samples still in the window, got %v", filtered)
+	}
+
+	filter.Forget(peer)
+	if filtered := filter.Filter(peer, 7.); filtered != 7. {
+		t.Fatalf("Peer history not forgotten, got %v", filtered)
+	}
+}
+
+func TestMedianFilter(t *testing.T) {
+	filter, err := NewMedianFilter(5)
+	if err != nil {
+		t.Fatalf("Unable to create filter, details: %s", err)
+	}
+
+	peer := guid.Guid{1}
+	var filtered float64
+	for _, rtt := range []float64{10., 100., 12., 1., 11.} {
+		filtered = filter.Filter(peer, rtt)
+	}
+	if filtered != 11. {
+		t.Fatalf("Wrong median %v, 11 expected", filtered)
+	}
+}
+
+func TestEWMAFilter(t *testing.T) {
+	if _, err := NewEWMAFilter(0.); err == nil {
+		t.Fatalf("Alpha 0 should be rejected")
+	}
+
+	filter, _ := NewEWMAFilter(0.5)
+	peer := guid.Guid{1}
+
+	filter.Filter(peer, 10.)
+	filter.Filter(peer, 20.)
+	if filtered := filter.Filter(peer, 40.); math.Abs(filtered-27.5) > 1e-9 {
+		t.Fatalf("Wrong average %v, 27.5 expected", filtered)
+	}
+}
@@ -0,0 +1,55 @@
+package nvs
+
+import (
+	"errors"
+	"math"
+)
+
+// Height vectors, as in the original Vivaldi paper, are laid out as the
+// euclidean components followed by the height, which models the access link
+// latency and is always non negative
+
+func heightDistance(first []float64, second []float64) float64 {
+	last := len(first) - 1
+	return euclideanNorm(first[:last], second[:last]) + first[last] + second[last]
+}
+
+func heightDifference(first []float64, second []float64) []float64 {
+	last := len(first) - 1
+	retVal := make([]float64, len(first))
+	for i := 0; i < last; i++ {
+		retVal[i] = first[i] - second[i]
+	}
+	retVal[last] = first[last] + second[last]
+
+	return retVal
+}
+
+func heightNormalize(vector []float64) []float64 {
+	last := len(vector) - 1
+	retVal := make([]float64, len(vector))
+	copy(retVal, vector)
+	retVal[last] = math.Max(retVal[last], 0.)
+
+	return retVal
+}
+
+var height_ops = NVSFunctions[float64]{
+	Distance:    heightDistance,
+	Rescaling:   euclideanRescale,
+	ExternalMul: euclideanExMul,
+	RandomEl:    euclideanRandomEl,
+	Zero:        euclideanZero,
+	Kind:        HeightVectorKind,
+	Difference:  heightDifference,
+	Normalize:   heightNormalize,
+}
+
+// NewHeightVectorSpace returns a space whose points have dim euclidean
+// coordinates plus a trailing height, so the space dimension is dim+1
+func NewHeightVectorSpace(dim int) (*NormedVectorSpace[float64], error) {
+	if dim <= 0 {
+		return nil, errors.New("dim should be greater than 0")
+	}
+	return NewNormedVectorSpace(dim+1, &height_ops)
+}
@@ -0,0 +1,50 @@
+package nvs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeightVectorDistance(t *testing.T) {
+	space, err := NewHeightVectorSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	first, _ := NewPoint(space, []float64{0., 0., 1.})
+	second, _ := NewPoint(space, []float64{3., 4., 2.})
+
+	dist, err := space.Distance(first, second)
+	if err != nil {
+		t.Fatalf("Unable to compute distance, details: %s", err)
+	}
+	if dist != 8. {
+		t.Fatalf("Wrong distance: expected 8, got %v", dist)
+	}
+
+	unit, err := space.UnitVector(first, second)
+	if err != nil {
+		t.Fatalf("Unable to compute unit vector, details: %s", err)
+	}
+	expected := []float64{-3. / 8., -4. / 8., 3. / 8.}
+	for i, coord := range unit.GetCoordinates() {
+		if math.Abs(coord-expected[i]) > 1e-12 {
+			t.Fatalf("Wrong unit vector: expected %v, got %v", expected, unit.GetCoordinates())
+		}
+	}
+}
+
+func TestHeightVectorNonNegativeHeight(t *testing.T) {
+	space, err := NewHeightVectorSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	point, _ := NewPoint(space, []float64{1., 1., 1.})
+	if !point.SetCoordinates([]float64{1., 1., -3.}) {
+		t.Fatalf("Unable to set coordinates")
+	}
+	if height := point.GetCoordinates()[2]; height != 0. {
+		t.Fatalf("Height should have been clamped to 0, got %v", height)
+	}
+}
@@ -0,0 +1,89 @@
+package nvs
+
+import (
+	"errors"
+	"math"
+)
+
+// Hyperbolic spaces use the Poincare ball model: with curvature -c the points lie
+// in the open ball of radius 1/sqrt(c), distances are close to the euclidean ones
+// near the center and grow logarithmically towards the boundary, which makes
+// tree-like topologies embeddable with low distortion
+
+// Points are kept this far from the boundary, where distances diverge
+const hyperbolicBoundaryMargin = 1e-5
+
+func dot(first []float64, second []float64) float64 {
+	sum := 0.
+	for i := range first {
+		sum += first[i] * second[i]
+	}
+	return sum
+}
+
+// mobiusAdd is the gyrovector addition of the ball, the hyperbolic translation of second by first
+func mobiusAdd(first []float64, second []float64, c float64) []float64 {
+	firstSecond, firstSq, secondSq := dot(first, second), dot(first, first), dot(second, second)
+	den := 1 + 2*c*firstSecond + c*c*firstSq*secondSq
+
+	retVal := make([]float64, len(first))
+	for i := range first {
+		retVal[i] = ((1+2*c*firstSecond+c*secondSq)*first[i] + (1-c*firstSq)*second[i]) / den
+	}
+	return retVal
+}
+
+func hyperbolicOps(c float64) *NVSFunctions[float64] {
+	sqrtC := math.Sqrt(c)
+
+	distance := func(first []float64, second []float64) float64 {
+		translated := mobiusAdd(euclideanExMul(first, -1.), second, c)
+		return 2. / sqrtC * math.Atanh(math.Min(sqrtC*math.Sqrt(dot(translated, translated)), 1.-1e-15))
+	}
+
+	//The tangent at first of the geodesic coming from second
+	difference := func(first []float64, second []float64) []float64 {
+		return euclideanExMul(mobiusAdd(euclideanExMul(first, -1.), second, c), -1.)
+	}
+
+	//Directions are tangent vectors, their length is the euclidean one whatever the distance
+	rescaling := func(vector []float64, _ float64) []float64 {
+		return euclideanRescale(vector, math.Sqrt(dot(vector, vector)))
+	}
+
+	normalize := func(vector []float64) []float64 {
+		maxNorm := (1. - hyperbolicBoundaryMargin) / sqrtC
+		norm := math.Sqrt(dot(vector, vector))
+		if norm <= maxNorm {
+			return vector
+		}
+		return euclideanExMul(vector, maxNorm/norm)
+	}
+
+	//The exponential map at point of the tangent vector long distance
+	step := func(point []float64, direction []float64, distance float64) []float64 {
+		return mobiusAdd(point, euclideanExMul(direction, math.Tanh(sqrtC*distance/2.)/sqrtC), c)
+	}
+
+	return &NVSFunctions[float64]{
+		Distance:    distance,
+		Rescaling:   rescaling,
+		ExternalMul: euclideanExMul,
+		RandomEl:    euclideanRandomEl,
+		Zero:        euclideanZero,
+		Kind:        HyperbolicKind,
+		Difference:  difference,
+		Normalize:   normalize,
+		Step:        step,
+	}
+}
+
+// NewHyperbolicSpace returns the Poincare ball of dimension dim and curvature -curvature.
+// Near the center a unit of coordinates is a unit of distance, the ball radius is
+// 1/sqrt(curvature): a small curvature suits distances measured in milliseconds
+func NewHyperbolicSpace(dim int, curvature float64) (*NormedVectorSpace[float64], error) {
+	if curvature <= 0 {
+		return nil, errors.New("curvature should be greater than 0")
+	}
+	return NewNormedVectorSpace(dim, hyperbolicOps(curvature))
+}
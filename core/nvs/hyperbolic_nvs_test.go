@@ -0,0 +1,63 @@
+package nvs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperbolicDistance(t *testing.T) {
+	space, err := NewHyperbolicSpace(2, 1.)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	origin, _ := NewPoint(space, []float64{0., 0.})
+	first, _ := NewPoint(space, []float64{0.5, 0.})
+	second, _ := NewPoint(space, []float64{0., -0.5})
+
+	dist, _ := space.Distance(origin, first)
+	if math.Abs(dist-2.*math.Atanh(0.5)) > 1e-12 {
+		t.Fatalf("Wrong distance from the center: %v", dist)
+	}
+
+	//arcosh(1 + 2|x-y|^2 / ((1-|x|^2)(1-|y|^2)))
+	expected := math.Acosh(1. + 2.*0.5/(0.75*0.75))
+	forth, _ := space.Distance(first, second)
+	back, _ := space.Distance(second, first)
+	if math.Abs(forth-expected) > 1e-12 || math.Abs(back-expected) > 1e-12 {
+		t.Fatalf("Wrong distance: expected %v, got %v and %v", expected, forth, back)
+	}
+}
+
+func TestHyperbolicStep(t *testing.T) {
+	space, err := NewHyperbolicSpace(3, 1e-4)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	first, _ := NewPoint(space, []float64{20., -10., 5.})
+	second, _ := NewPoint(space, []float64{-30., 40., 0.})
+	initial, _ := space.Distance(first, second)
+
+	for _, step := range []float64{15., -15.} {
+		moved, err := space.Step(first, second, step)
+		if err != nil {
+			t.Fatalf("Unable to step, details: %s", err)
+		}
+		travelled, _ := space.Distance(first, moved)
+		final, _ := space.Distance(moved, second)
+		if math.Abs(travelled-math.Abs(step)) > 1e-6 || math.Abs(final-(initial+step)) > 1e-6 {
+			t.Fatalf("Step %v should move along the geodesic, travelled %v and %v from the other point, %v before",
+				step, travelled, final, initial)
+		}
+	}
+
+	//Coordinates never reach the boundary
+	far, err := space.Step(first, second, 1e6)
+	if err != nil {
+		t.Fatalf("Unable to step, details: %s", err)
+	}
+	if norm := math.Sqrt(dot(far.GetCoordinates(), far.GetCoordinates())); norm >= 100. {
+		t.Fatalf("Point outside the ball, norm %v", norm)
+	}
+}
@@ -0,0 +1,68 @@
+package nvs
+
+import (
+	"errors"
+	"math"
+)
+
+// Minkowski spaces measure the distance with the weighted Lp norm
+// (sum of (w_i*|x_i-y_i|)^p)^(1/p), whose limit for p to infinity is max of w_i*|x_i-y_i|.
+// Unit vectors have unit Lp norm, so moving along one changes the distance by the
+// length moved exactly as in the euclidean space
+
+func minkowskiDistance(p float64, weights []float64) func([]float64, []float64) float64 {
+	return func(first []float64, second []float64) float64 {
+		largest := 0.
+		for i := range first {
+			largest = math.Max(largest, weights[i]*math.Abs(first[i]-second[i]))
+		}
+		if math.IsInf(p, 1) || largest == 0 {
+			return largest
+		}
+
+		//Scaling by the largest term keeps high powers from overflowing
+		sum := 0.
+		for i := range first {
+			sum += math.Pow(weights[i]*math.Abs(first[i]-second[i])/largest, p)
+		}
+		return largest * math.Pow(sum, 1./p)
+	}
+}
+
+// NewWeightedMinkowskiSpace returns the real space of dimension dim with the Lp norm weighting
+// every coordinate, p goes from 1 to math.Inf(1) included
+func NewWeightedMinkowskiSpace(dim int, p float64, weights []float64) (*NormedVectorSpace[float64], error) {
+	if dim <= 0 {
+		return nil, errors.New("dim should be greater than 0")
+	}
+	if math.IsNaN(p) || p < 1 {
+		return nil, errors.New("p should be at least 1")
+	}
+	if len(weights) != dim {
+		return nil, errors.New("there should be a weight for every dimension")
+	}
+	for _, weight := range weights {
+		if weight <= 0 || math.IsInf(weight, 1) {
+			return nil, errors.New("weights should be positive")
+		}
+	}
+
+	return NewNormedVectorSpace(dim, &NVSFunctions[float64]{
+		Distance:    minkowskiDistance(p, append(make([]float64, 0, dim), weights...)),
+		Rescaling:   euclideanRescale,
+		ExternalMul: euclideanExMul,
+		RandomEl:    euclideanRandomEl,
+		Zero:        euclideanZero,
+		Kind:        MinkowskiKind,
+	})
+}
+
+// NewMinkowskiSpace returns the real space of dimension dim with the Lp norm,
+// p = 1 gives the manhattan distance and p = math.Inf(1) the chebyshev one
+func NewMinkowskiSpace(dim int, p float64) (*NormedVectorSpace[float64], error) {
+	weights := make([]float64, max(dim, 0))
+	for i := range weights {
+		weights[i] = 1.
+	}
+	return NewWeightedMinkowskiSpace(dim, p, weights)
+}
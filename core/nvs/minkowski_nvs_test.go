@@ -0,0 +1,65 @@
+package nvs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinkowskiDistance(t *testing.T) {
+	weighted, err := NewWeightedMinkowskiSpace(2, 2., []float64{1., 2.})
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	for _, test := range []struct {
+		p        float64
+		space    *NormedVectorSpace[float64]
+		expected float64
+	}{
+		{p: 1.},
+		{p: 2.},
+		{p: 3.},
+		{p: math.Inf(1)},
+		{space: weighted, expected: math.Sqrt(9. + 64.)},
+	} {
+		space := test.space
+		if space == nil {
+			space, err = NewMinkowskiSpace(2, test.p)
+			if err != nil {
+				t.Fatalf("Unable to create space, details: %s", err)
+			}
+			if math.IsInf(test.p, 1) {
+				test.expected = 4.
+			} else {
+				test.expected = math.Pow(math.Pow(3., test.p)+math.Pow(4., test.p), 1./test.p)
+			}
+		}
+
+		first, _ := NewPoint(space, []float64{1., 1.})
+		second, _ := NewPoint(space, []float64{-2., 5.})
+		if dist, _ := space.Distance(first, second); math.Abs(dist-test.expected) > 1e-12 {
+			t.Fatalf("Wrong distance for p %v: expected %v, got %v", test.p, test.expected, dist)
+		}
+
+		//Stepping along the unit vector changes the distance by the step
+		moved, err := space.Step(first, second, 2.)
+		if err != nil {
+			t.Fatalf("Unable to step, details: %s", err)
+		}
+		if dist, _ := space.Distance(moved, second); math.Abs(dist-test.expected-2.) > 1e-9 {
+			t.Fatalf("Wrong distance after the step for p %v: expected %v, got %v", test.p, test.expected+2., dist)
+		}
+	}
+}
+
+func TestMinkowskiParameters(t *testing.T) {
+	if _, err := NewMinkowskiSpace(2, 0.5); err == nil {
+		t.Fatalf("p below 1 is not a norm")
+	}
+	if _, err := NewWeightedMinkowskiSpace(2, 2., []float64{1.}); err == nil {
+		t.Fatalf("Weights not matching the dimension should be rejected")
+	}
+	if _, err := NewWeightedMinkowskiSpace(2, 2., []float64{1., 0.}); err == nil {
+		t.Fatalf("Zero weights should be rejected")
+	}
+}
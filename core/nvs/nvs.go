@@ -5,21 +5,46 @@ import (
 	"fmt"
 )
 
+const (
+	CustomKind       = "custom"
+	EuclideanKind    = "euclidean"
+	HeightVectorKind = "height-vector"
+	HyperbolicKind   = "hyperbolic"
+	SphericalKind    = "spherical"
+	MinkowskiKind    = "minkowski"
+	UnitaryKind      = "unitary"
+)
+
 type NVSFunctions[SUPPORT float64 | complex128] struct {
 	Distance    func([]SUPPORT, []SUPPORT) float64
 	Rescaling   func([]SUPPORT, float64) []SUPPORT
 	ExternalMul func([]SUPPORT, float64) []SUPPORT
 	RandomEl    func() SUPPORT
 	Zero        func(int) []SUPPORT
+
+	// Optional: Kind names the space family, CustomKind is used if empty
+	Kind string
+	// Optional: Difference returns the vector going from the second point to the first one,
+	// component-wise subtraction is used if nil
+	Difference func([]SUPPORT, []SUPPORT) []SUPPORT
+	// Optional: Normalize maps coordinates set on a point back inside the space
+	Normalize func([]SUPPORT) []SUPPORT
+	// Optional: Step moves a point along the geodesic leaving it in the unit direction given,
+	// for the given distance. Adding the rescaled direction is used if nil
+	Step func([]SUPPORT, []SUPPORT, float64) []SUPPORT
 }
 
 type NormedVectorSpace[SUPPORT float64 | complex128] struct {
 	dimension   int
+	kind        string
 	distance    func([]SUPPORT, []SUPPORT) float64
 	rescaling   func([]SUPPORT, float64) []SUPPORT
 	externalMul func([]SUPPORT, float64) []SUPPORT
 	randomEl    func() SUPPORT
 	zero        func(int) []SUPPORT
+	difference  func([]SUPPORT, []SUPPORT) []SUPPORT
+	normalize   func([]SUPPORT) []SUPPORT
+	step        func([]SUPPORT, []SUPPORT, float64) []SUPPORT
 }
 
 func (nvs *NormedVectorSpace[SUPPORT]) Distance(first *Point[SUPPORT], second *Point[SUPPORT]) (float64, error) {
@@ -37,6 +62,20 @@ func (nvs *NormedVectorSpace[SUPPORT]) Dimension() int {
 	return nvs.dimension
 }
 
+func (nvs *NormedVectorSpace[SUPPORT]) Kind() string {
+	return nvs.kind
+}
+
+// SetRandomEl replaces the source of the random directions used for coincident
+// points, useful to make runs reproducible through a seeded generator
+func (nvs *NormedVectorSpace[SUPPORT]) SetRandomEl(randomEl func() SUPPORT) error {
+	if randomEl == nil {
+		return errors.New("the random element generator should not be nil")
+	}
+	nvs.randomEl = randomEl
+	return nil
+}
+
 func (nvs *NormedVectorSpace[SUPPORT]) UnitVector(first *Point[SUPPORT], second *Point[SUPPORT]) (*Point[SUPPORT], error) {
 	if nvs.dimension <= 0 || nvs.distance == nil {
 		return nil, errors.New("dim should be greater than 0 and distance should not be nil")
@@ -53,12 +92,7 @@ func (nvs *NormedVectorSpace[SUPPORT]) UnitVector(first *Point[SUPPORT], second
 	var rescaled []SUPPORT
 
 	if norm != 0. {
-		toRescale := make([]SUPPORT, nvs.dimension)
-		for i := 0; i < nvs.dimension; i++ {
-			toRescale[i] = first.GetCoordinates()[i] - second.coordinates[i]
-		}
-
-		rescaled = nvs.rescaling(toRescale, norm)
+		rescaled = nvs.rescaling(nvs.difference(first.coordinates, second.coordinates), norm)
 	} else {
 		rescaled = make([]SUPPORT, nvs.dimension)
 	RESCALED:
@@ -94,28 +128,155 @@ func (nvs *NormedVectorSpace[SUPPORT]) ExternalMul(pt *Point[SUPPORT], val float
 	return NewPoint(nvs, newCoords)
 }
 
+// Step moves first for distance along the geodesic through second, away from it
+// when distance is positive and towards it otherwise
+func (nvs *NormedVectorSpace[SUPPORT]) Step(first *Point[SUPPORT], second *Point[SUPPORT], distance float64) (*Point[SUPPORT], error) {
+	unit, err := nvs.UnitVector(first, second)
+	if err != nil {
+		return nil, fmt.Errorf("error in unit vector evaluation, details: %s", err)
+	}
+
+	var newCoords []SUPPORT
+	if nvs.step != nil {
+		newCoords = nvs.step(first.coordinates, unit.coordinates, distance)
+	} else {
+		move := nvs.externalMul(unit.coordinates, distance)
+		newCoords = make([]SUPPORT, nvs.dimension)
+		for i := 0; i < nvs.dimension; i++ {
+			newCoords[i] = first.coordinates[i] + move[i]
+		}
+	}
+
+	if nvs.normalize != nil {
+		newCoords = nvs.normalize(newCoords)
+	}
+
+	return NewPoint(nvs, newCoords)
+}
+
+// The arithmetic below works component-wise on the coordinates, as in a vector space:
+// results are not normalized, use Step to move along the geodesics of curved spaces
+
+func (nvs *NormedVectorSpace[SUPPORT]) Add(first *Point[SUPPORT], second *Point[SUPPORT]) (*Point[SUPPORT], error) {
+	if first.space != nvs || second.space != nvs {
+		return nil, errors.New("the points do not belong to this space")
+	}
+
+	newCoords := make([]SUPPORT, nvs.dimension)
+	for i := 0; i < nvs.dimension; i++ {
+		newCoords[i] = first.coordinates[i] + second.coordinates[i]
+	}
+
+	return NewPoint(nvs, newCoords)
+}
+
+// Sub returns first minus second
+func (nvs *NormedVectorSpace[SUPPORT]) Sub(first *Point[SUPPORT], second *Point[SUPPORT]) (*Point[SUPPORT], error) {
+	if first.space != nvs || second.space != nvs {
+		return nil, errors.New("the points do not belong to this space")
+	}
+
+	return NewPoint(nvs, componentDifference(first.coordinates, second.coordinates))
+}
+
+// Lerp returns first + t*(second - first): first for t = 0 and second for t = 1
+func (nvs *NormedVectorSpace[SUPPORT]) Lerp(first *Point[SUPPORT], second *Point[SUPPORT], t float64) (*Point[SUPPORT], error) {
+	if first.space != nvs || second.space != nvs {
+		return nil, errors.New("the points do not belong to this space")
+	}
+
+	move := nvs.externalMul(componentDifference(second.coordinates, first.coordinates), t)
+	newCoords := make([]SUPPORT, nvs.dimension)
+	for i := 0; i < nvs.dimension; i++ {
+		newCoords[i] = first.coordinates[i] + move[i]
+	}
+
+	return NewPoint(nvs, newCoords)
+}
+
+// Norm returns the distance of pt from the origin
+func (nvs *NormedVectorSpace[SUPPORT]) Norm(pt *Point[SUPPORT]) (float64, error) {
+	if pt.space != nvs {
+		return -1., errors.New("the point does not belong to this space")
+	}
+
+	return nvs.distance(pt.coordinates, nvs.zero(nvs.dimension)), nil
+}
+
+// Centroid returns the mean of points, which should not be empty
+func (nvs *NormedVectorSpace[SUPPORT]) Centroid(points ...*Point[SUPPORT]) (*Point[SUPPORT], error) {
+	if len(points) == 0 {
+		return nil, errors.New("the centroid of no points is undefined")
+	}
+
+	sum := nvs.zero(nvs.dimension)
+	for _, pt := range points {
+		if pt.space != nvs {
+			return nil, errors.New("the points do not belong to this space")
+		}
+		for i := 0; i < nvs.dimension; i++ {
+			sum[i] += pt.coordinates[i]
+		}
+	}
+
+	return NewPoint(nvs, nvs.externalMul(sum, 1./float64(len(points))))
+}
+
+// Clone returns a point of the space with the coordinates of pt, sharing no memory with it
+func (nvs *NormedVectorSpace[SUPPORT]) Clone(pt *Point[SUPPORT]) (*Point[SUPPORT], error) {
+	if pt.space != nvs {
+		return nil, errors.New("the point does not belong to this space")
+	}
+
+	return NewPoint(nvs, pt.GetCoordinates())
+}
+
 func NewNormedVectorSpace[SUPPORT float64 | complex128](dim int, ops *NVSFunctions[SUPPORT]) (*NormedVectorSpace[SUPPORT], error) {
 
 	if dim <= 0 || ops.Distance == nil || ops.ExternalMul == nil || ops.RandomEl == nil || ops.Rescaling == nil || ops.Zero == nil {
 		return nil, errors.New("dim should be greater than 0 and no opration should be nil")
 	}
+
+	kind := ops.Kind
+	if kind == "" {
+		kind = CustomKind
+	}
+	difference := ops.Difference
+	if difference == nil {
+		difference = componentDifference[SUPPORT]
+	}
+
 	return &NormedVectorSpace[SUPPORT]{
 		dimension:   dim,
+		kind:        kind,
 		distance:    ops.Distance,
 		rescaling:   ops.Rescaling,
 		externalMul: ops.ExternalMul,
 		randomEl:    ops.RandomEl,
 		zero:        ops.Zero,
+		difference:  difference,
+		normalize:   ops.Normalize,
+		step:        ops.Step,
 	}, nil
 }
 
+func componentDifference[SUPPORT float64 | complex128](first []SUPPORT, second []SUPPORT) []SUPPORT {
+	retVal := make([]SUPPORT, len(first))
+	for i := 0; i < len(first); i++ {
+		retVal[i] = first[i] - second[i]
+	}
+
+	return retVal
+}
+
 type Point[SUPPORT float64 | complex128] struct {
 	space       *NormedVectorSpace[SUPPORT]
 	coordinates []SUPPORT
 }
 
+// GetCoordinates returns a copy of the coordinates, changing it does not move the point
 func (pt *Point[SUPPORT]) GetCoordinates() []SUPPORT {
-	return pt.coordinates
+	return append(make([]SUPPORT, 0, len(pt.coordinates)), pt.coordinates...)
 }
 
 func (pt *Point[SUPPORT]) SetCoordinates(coords []SUPPORT) bool {
@@ -123,7 +284,11 @@ func (pt *Point[SUPPORT]) SetCoordinates(coords []SUPPORT) bool {
 		return false
 	}
 
-	pt.coordinates = coords
+	if pt.space.normalize != nil {
+		coords = pt.space.normalize(coords)
+	}
+
+	pt.coordinates = append(make([]SUPPORT, 0, len(coords)), coords...)
 	return true
 }
 
@@ -132,5 +297,5 @@ func NewPoint[SUPPORT float64 | complex128](space *NormedVectorSpace[SUPPORT], c
 		return nil, errors.New("the point is incompatible with the requested space")
 	}
 
-	return &Point[SUPPORT]{space: space, coordinates: coords}, nil
+	return &Point[SUPPORT]{space: space, coordinates: append(make([]SUPPORT, 0, len(coords)), coords...)}, nil
 }
@@ -0,0 +1,113 @@
+package nvs
+
+import (
+	"math"
+	"testing"
+)
+
+func checkCoords(t *testing.T, pt *Point[float64], expected []float64) {
+	coords := pt.GetCoordinates()
+	for i := range expected {
+		if math.Abs(coords[i]-expected[i]) > 1e-12 {
+			t.Fatalf("Expected coordinates %v, got %v", expected, coords)
+		}
+	}
+}
+
+func TestPointArithmetic(t *testing.T) {
+	space, err := NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	first, _ := NewPoint(space, []float64{1., 2.})
+	second, _ := NewPoint(space, []float64{4., 6.})
+
+	sum, err := space.Add(first, second)
+	if err != nil {
+		t.Fatalf("Unable to add, details: %s", err)
+	}
+	checkCoords(t, sum, []float64{5., 8.})
+
+	diff, err := space.Sub(second, first)
+	if err != nil {
+		t.Fatalf("Unable to subtract, details: %s", err)
+	}
+	checkCoords(t, diff, []float64{3., 4.})
+
+	if norm, _ := space.Norm(diff); math.Abs(norm-5.) > 1e-12 {
+		t.Fatalf("Expected norm 5, got %f", norm)
+	}
+
+	for _, test := range []struct {
+		t        float64
+		expected []float64
+	}{
+		{0., []float64{1., 2.}},
+		{0.5, []float64{2.5, 4.}},
+		{1., []float64{4., 6.}},
+		{2., []float64{7., 10.}},
+	} {
+		lerp, err := space.Lerp(first, second, test.t)
+		if err != nil {
+			t.Fatalf("Unable to interpolate, details: %s", err)
+		}
+		checkCoords(t, lerp, test.expected)
+	}
+
+	third, _ := NewPoint(space, []float64{-2., 1.})
+	centroid, err := space.Centroid(first, second, third)
+	if err != nil {
+		t.Fatalf("Unable to compute centroid, details: %s", err)
+	}
+	checkCoords(t, centroid, []float64{1., 3.})
+	if _, err = space.Centroid(); err == nil {
+		t.Fatalf("Expected the centroid of no points to be refused")
+	}
+
+	other, _ := NewRealEuclideanSpace(2)
+	alien, _ := NewPoint(other, []float64{1., 2.})
+	if _, err = space.Add(first, alien); err == nil {
+		t.Fatalf("Expected points of another space to be refused by Add")
+	}
+	if _, err = space.Sub(first, alien); err == nil {
+		t.Fatalf("Expected points of another space to be refused by Sub")
+	}
+	if _, err = space.Lerp(first, alien, 0.5); err == nil {
+		t.Fatalf("Expected points of another space to be refused by Lerp")
+	}
+	if _, err = space.Norm(alien); err == nil {
+		t.Fatalf("Expected points of another space to be refused by Norm")
+	}
+	if _, err = space.Centroid(first, alien); err == nil {
+		t.Fatalf("Expected points of another space to be refused by Centroid")
+	}
+	if _, err = space.Clone(alien); err == nil {
+		t.Fatalf("Expected points of another space to be refused by Clone")
+	}
+}
+
+func TestPointAliasing(t *testing.T) {
+	space, err := NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	coords := []float64{1., 2.}
+	pt, _ := NewPoint(space, coords)
+	coords[0] = 100.
+	pt.GetCoordinates()[1] = 100.
+	checkCoords(t, pt, []float64{1., 2.})
+
+	coords = []float64{3., 4.}
+	pt.SetCoordinates(coords)
+	coords[0] = 100.
+	checkCoords(t, pt, []float64{3., 4.})
+
+	clone, err := space.Clone(pt)
+	if err != nil {
+		t.Fatalf("Unable to clone, details: %s", err)
+	}
+	pt.SetCoordinates([]float64{5., 6.})
+	checkCoords(t, clone, []float64{3., 4.})
+}
@@ -51,6 +51,7 @@ var euclidean_ops = NVSFunctions[float64]{
 	ExternalMul: euclideanExMul,
 	RandomEl:    euclideanRandomEl,
 	Zero:        euclideanZero,
+	Kind:        EuclideanKind,
 }
 
 func NewRealEuclideanSpace(dim int) (*NormedVectorSpace[float64], error) {
@@ -0,0 +1,112 @@
+package nvs
+
+import (
+	"errors"
+	"math"
+)
+
+// Spherical spaces lay the points on the surface of a sphere centered in the origin,
+// stored as their cartesian coordinates. Distances are great circle distances, so
+// no pair of points is farther than half the circumference
+
+// SphereRadiusForMaxRTT returns the radius whose half circumference is maxRTT
+func SphereRadiusForMaxRTT(maxRTT float64) float64 {
+	return maxRTT / math.Pi
+}
+
+// onUnitSphere projects a vector on the unit sphere, the origin goes to the first axis
+func onUnitSphere(vector []float64) []float64 {
+	norm := math.Sqrt(dot(vector, vector))
+	if norm == 0 {
+		retVal := make([]float64, len(vector))
+		retVal[0] = 1.
+		return retVal
+	}
+	return euclideanRescale(vector, norm)
+}
+
+// tangent removes from vector its component along the unit vector point
+func tangent(vector []float64, point []float64) []float64 {
+	projection := dot(vector, point)
+	retVal := make([]float64, len(vector))
+	for i := range vector {
+		retVal[i] = vector[i] - projection*point[i]
+	}
+	return retVal
+}
+
+func sphericalOps(radius float64) *NVSFunctions[float64] {
+
+	//The angle between the points, robust for close and antipodal points alike
+	distance := func(first []float64, second []float64) float64 {
+		firstUnit, secondUnit := onUnitSphere(first), onUnitSphere(second)
+		diff, sum := make([]float64, len(first)), make([]float64, len(first))
+		for i := range first {
+			diff[i] = firstUnit[i] - secondUnit[i]
+			sum[i] = firstUnit[i] + secondUnit[i]
+		}
+		return radius * 2. * math.Atan2(math.Sqrt(dot(diff, diff)), math.Sqrt(dot(sum, sum)))
+	}
+
+	//The tangent at first of the great circle coming from second
+	difference := func(first []float64, second []float64) []float64 {
+		return euclideanExMul(tangent(onUnitSphere(second), onUnitSphere(first)), -1.)
+	}
+
+	//Directions are tangent vectors, their length is the euclidean one whatever the distance
+	rescaling := func(vector []float64, _ float64) []float64 {
+		norm := math.Sqrt(dot(vector, vector))
+		if norm == 0 {
+			return vector
+		}
+		return euclideanRescale(vector, norm)
+	}
+
+	normalize := func(vector []float64) []float64 {
+		return euclideanExMul(onUnitSphere(vector), radius)
+	}
+
+	//Walks the great circle leaving point in direction, which is made tangent first: antipodal
+	//points and random directions do not give a tangent one
+	step := func(point []float64, direction []float64, distance float64) []float64 {
+		pointUnit := onUnitSphere(point)
+		towards := tangent(direction, pointUnit)
+		for axis := 0; dot(towards, towards) < 1e-18 && axis < len(point); axis++ {
+			basis := make([]float64, len(point))
+			basis[axis] = 1.
+			towards = tangent(basis, pointUnit)
+		}
+		towards = euclideanRescale(towards, math.Sqrt(dot(towards, towards)))
+
+		angle := distance / radius
+		retVal := make([]float64, len(point))
+		for i := range point {
+			retVal[i] = radius * (math.Cos(angle)*pointUnit[i] + math.Sin(angle)*towards[i])
+		}
+		return retVal
+	}
+
+	return &NVSFunctions[float64]{
+		Distance:    distance,
+		Rescaling:   rescaling,
+		ExternalMul: euclideanExMul,
+		RandomEl:    euclideanRandomEl,
+		Zero:        euclideanZero,
+		Kind:        SphericalKind,
+		Difference:  difference,
+		Normalize:   normalize,
+		Step:        step,
+	}
+}
+
+// NewSphericalSpace returns the surface of dimension dim of a sphere of the given radius,
+// its points have dim+1 coordinates. The origin is read as the point on the first axis
+func NewSphericalSpace(dim int, radius float64) (*NormedVectorSpace[float64], error) {
+	if dim <= 0 {
+		return nil, errors.New("dim should be greater than 0")
+	}
+	if radius <= 0 {
+		return nil, errors.New("radius should be greater than 0")
+	}
+	return NewNormedVectorSpace(dim+1, sphericalOps(radius))
+}
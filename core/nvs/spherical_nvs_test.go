@@ -0,0 +1,59 @@
+package nvs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSphericalDistance(t *testing.T) {
+	space, err := NewSphericalSpace(2, SphereRadiusForMaxRTT(200.))
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	first, _ := NewPoint(space, []float64{1., 0., 0.})
+	second, _ := NewPoint(space, []float64{0., 5., 0.})
+	antipode, _ := NewPoint(space, []float64{-2., 0., 0.})
+
+	if dist, _ := space.Distance(first, second); math.Abs(dist-100.) > 1e-9 {
+		t.Fatalf("Wrong distance: expected 100, got %v", dist)
+	}
+	if dist, _ := space.Distance(first, antipode); math.Abs(dist-200.) > 1e-9 {
+		t.Fatalf("Antipodal points should be the max RTT away, got %v", dist)
+	}
+}
+
+func TestSphericalStep(t *testing.T) {
+	radius := SphereRadiusForMaxRTT(300.)
+	space, _ := NewSphericalSpace(2, radius)
+
+	first, _ := NewPoint(space, []float64{radius, 0., 0.})
+	second, _ := NewPoint(space, []float64{0., radius * math.Cos(0.3), radius * math.Sin(0.3)})
+	initial, _ := space.Distance(first, second)
+
+	for _, step := range []float64{20., -20.} {
+		moved, err := space.Step(first, second, step)
+		if err != nil {
+			t.Fatalf("Unable to step, details: %s", err)
+		}
+		if norm := math.Sqrt(dot(moved.GetCoordinates(), moved.GetCoordinates())); math.Abs(norm-radius) > 1e-9 {
+			t.Fatalf("Point left the sphere, norm %v", norm)
+		}
+		travelled, _ := space.Distance(first, moved)
+		final, _ := space.Distance(moved, second)
+		if math.Abs(travelled-math.Abs(step)) > 1e-9 || math.Abs(final-(initial+step)) > 1e-9 {
+			t.Fatalf("Step %v should move along the great circle, travelled %v and %v from the other point, %v before",
+				step, travelled, final, initial)
+		}
+	}
+
+	//From the antipode every direction is a geodesic
+	antipode, _ := NewPoint(space, []float64{-radius, 0., 0.})
+	moved, err := space.Step(first, antipode, -10.)
+	if err != nil {
+		t.Fatalf("Unable to step, details: %s", err)
+	}
+	if travelled, _ := space.Distance(first, moved); math.Abs(travelled-10.) > 1e-9 {
+		t.Fatalf("Step towards the antipode travelled %v", travelled)
+	}
+}
@@ -0,0 +1,56 @@
+package nvs
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Unitary spaces are the complex coordinate spaces with the hermitian norm,
+// the square root of the sum of the squared moduli of the components
+
+func unitaryNorm(first []complex128, second []complex128) float64 {
+	sum := 0.
+	for i := 0; i < len(first); i++ {
+		diff := first[i] - second[i]
+		sum += real(diff)*real(diff) + imag(diff)*imag(diff)
+	}
+
+	return math.Sqrt(sum)
+}
+
+func unitaryRescale(vector []complex128, norm float64) []complex128 {
+	return unitaryExMul(vector, 1./norm)
+}
+
+func unitaryExMul(vector []complex128, val float64) []complex128 {
+	retVal := make([]complex128, len(vector))
+	for i, entry := range vector {
+		retVal[i] = entry * complex(val, 0.)
+	}
+
+	return retVal
+}
+
+// unitaryRandomEl draws both parts from a normal distribution, so that the
+// random directions are uniform over the unit sphere whatever their phase
+func unitaryRandomEl() complex128 {
+	return complex(rand.NormFloat64(), rand.NormFloat64())
+}
+
+func unitaryZero(dim int) []complex128 {
+	return make([]complex128, dim)
+}
+
+var unitary_ops = NVSFunctions[complex128]{
+	Distance:    unitaryNorm,
+	Rescaling:   unitaryRescale,
+	ExternalMul: unitaryExMul,
+	RandomEl:    unitaryRandomEl,
+	Zero:        unitaryZero,
+	Kind:        UnitaryKind,
+}
+
+// NewUnitarySpace returns the complex space of dimension dim with the hermitian norm
+func NewUnitarySpace(dim int) (*NormedVectorSpace[complex128], error) {
+	return NewNormedVectorSpace(dim, &unitary_ops)
+}
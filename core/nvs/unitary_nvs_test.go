@@ -0,0 +1,64 @@
+package nvs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitaryDistance(t *testing.T) {
+	space, err := NewUnitarySpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+	if space.Kind() != UnitaryKind {
+		t.Fatalf("Expected kind %s, got %s", UnitaryKind, space.Kind())
+	}
+
+	first, _ := NewPoint(space, []complex128{1 + 1i, 2i})
+	second, _ := NewPoint(space, []complex128{-2 + 5i, 2i})
+	if dist, _ := space.Distance(first, second); math.Abs(dist-5.) > 1e-12 {
+		t.Fatalf("Expected distance 5, got %f", dist)
+	}
+
+	//Purely imaginary offsets count as much as real ones
+	third, _ := NewPoint(space, []complex128{1 + 1i, 5i})
+	if dist, _ := space.Distance(first, third); math.Abs(dist-3.) > 1e-12 {
+		t.Fatalf("Expected distance 3, got %f", dist)
+	}
+}
+
+func TestUnitaryStep(t *testing.T) {
+	space, err := NewUnitarySpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	first, _ := NewPoint(space, []complex128{1i, 0})
+	second, _ := NewPoint(space, []complex128{3i, 0})
+	stepped, err := space.Step(first, second, 2.)
+	if err != nil {
+		t.Fatalf("Unable to step, details: %s", err)
+	}
+	if dist, _ := space.Distance(stepped, second); math.Abs(dist-4.) > 1e-12 {
+		t.Fatalf("Expected distance 4 after stepping away, got %f", dist)
+	}
+
+	//Coincident points move in a random direction, which has both real and imaginary parts
+	imaginary := false
+	for i := 0; i < 10; i++ {
+		unit, err := space.UnitVector(first, first)
+		if err != nil {
+			t.Fatalf("Unable to get unit vector, details: %s", err)
+		}
+		zero, _ := NewPoint(space, []complex128{0, 0})
+		if norm, _ := space.Distance(unit, zero); math.Abs(norm-1.) > 1e-12 {
+			t.Fatalf("Expected unit norm, got %f", norm)
+		}
+		for _, coord := range unit.GetCoordinates() {
+			imaginary = imaginary || imag(coord) != 0
+		}
+	}
+	if !imaginary {
+		t.Fatalf("Random directions have no imaginary part")
+	}
+}
@@ -1,8 +1,10 @@
 package gossip
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
 	"time"
 
 	"github.com/sebastianopriscan/GNCFD/communication"
@@ -18,6 +20,14 @@ type MessageToForward struct {
 	Payload   any
 }
 
+type GossipMode int
+
+const (
+	PushMode GossipMode = iota
+	PullMode
+	ExchangeMode
+)
+
 type messageHistory struct {
 	patience           int
 	already_sent_peers map[guid.Guid]guid.Guid
@@ -25,6 +35,8 @@ type messageHistory struct {
 
 type BlindCounterGossiper struct {
 	channelobserver.ChannelObserverObserver
+	//Membership events are pushed as *PeerEvent
+	channelobserver.ChannelObserverSubjectImpl
 
 	peers *lockedmap.LockedMap[guid.Guid, communication.GNCFDCommunicationChannel]
 	core  core.GNCFDCoreInteractionGate
@@ -32,7 +44,17 @@ type BlindCounterGossiper struct {
 	B int
 	F int
 
-	inputchann chan bool
+	inputchann chan GossipMode
+	//Fan-in of every observed subject channel
+	events chan any
+	//Closed to stop relaying the events of a subject
+	relays lockedmap.LockedMap[channelobserver.ChannelObserverSubject, chan bool]
+
+	roundInterval time.Duration
+	roundJitter   time.Duration
+	roundMode     GossipMode
+
+	selector PeerSelector
 
 	history lockedmap.LockedMap[guid.Guid, *messageHistory]
 
@@ -43,13 +65,17 @@ func NewBlindCounterGossiper(peerMap *lockedmap.LockedMap[guid.Guid, communicati
 	retVal := &BlindCounterGossiper{peers: peerMap,
 		core: core, B: B, F: F,
 
-		inputchann: make(chan bool, 10),
+		inputchann: make(chan GossipMode, 10),
+		events:     make(chan any, 10),
+		relays:     lockedmap.LockedMap[channelobserver.ChannelObserverSubject, chan bool]{Map: make(map[channelobserver.ChannelObserverSubject]chan bool)},
+		selector:   NewRandomSelector(time.Now().UnixNano()),
 		history:    lockedmap.LockedMap[guid.Guid, *messageHistory]{Map: make(map[guid.Guid]*messageHistory)},
 		ChannelObserverObserver: channelobserver.ChannelObserverObserver{
 			Registrations: lockedmap.LockedMap[channelobserver.ChannelObserverSubject, channelobserver.Chancode]{
 				Map: make(map[channelobserver.ChannelObserverSubject]channelobserver.Chancode),
 			},
 		},
+		ChannelObserverSubjectImpl: channelobserver.NewChannelObserverSubjectImpl(),
 	}
 
 	asSubject, ok := core.(channelobserver.ChannelObserverSubject)
@@ -62,6 +88,49 @@ func NewBlindCounterGossiper(peerMap *lockedmap.LockedMap[guid.Guid, communicati
 	return retVal
 }
 
+// SetGossipRound makes the gossiper start a round in the given mode every interval,
+// randomly anticipated or delayed up to jitter. It must be called before StartGossiping,
+// a zero interval disables the periodic rounds
+func (bgc *BlindCounterGossiper) SetGossipRound(interval time.Duration, jitter time.Duration, mode GossipMode) error {
+	if interval < 0 || jitter < 0 || jitter >= interval && interval != 0 {
+		return errors.New("interval and jitter should be positive, jitter should be smaller than interval")
+	}
+	if mode != PushMode && mode != PullMode && mode != ExchangeMode {
+		return errors.New("unknown gossip mode")
+	}
+	if bgc.stopchann != nil {
+		return errors.New("gossip rounds should be set before starting gossiping")
+	}
+
+	bgc.roundInterval = interval
+	bgc.roundJitter = jitter
+	bgc.roundMode = mode
+
+	return nil
+}
+
+// SetPeerSelector replaces the default uniform random selection of the fan-out,
+// it must be called before StartGossiping
+func (bgc *BlindCounterGossiper) SetPeerSelector(selector PeerSelector) error {
+	if selector == nil {
+		return errors.New("peer selector should not be nil")
+	}
+	if bgc.stopchann != nil {
+		return errors.New("peer selector should be set before starting gossiping")
+	}
+
+	bgc.selector = selector
+
+	return nil
+}
+
+func (bgc *BlindCounterGossiper) nextRound() time.Duration {
+	if bgc.roundJitter == 0 {
+		return bgc.roundInterval
+	}
+	return bgc.roundInterval - bgc.roundJitter + time.Duration(rand.Int63n(int64(2*bgc.roundJitter)))
+}
+
 func (bgc *BlindCounterGossiper) StartGossiping() bool {
 
 	if bgc.stopchann != nil {
@@ -85,38 +154,50 @@ func (bgc *BlindCounterGossiper) StopGossiping() {
 }
 
 func (bgc *BlindCounterGossiper) InsertGossip() bool {
+	return bgc.InsertGossipRound(PushMode)
+}
+
+// InsertGossipRound starts a round in the given mode out of the periodic schedule
+func (bgc *BlindCounterGossiper) InsertGossipRound(mode GossipMode) bool {
 	if bgc.stopchann == nil {
 		return false
 	}
-	bgc.inputchann <- true
+	bgc.inputchann <- mode
 	return true
 }
 
-func do_gossip_forward(bcg *BlindCounterGossiper, msg_history *messageHistory, forwdMsg *MessageToForward) {
-
-	msg_history.already_sent_peers[forwdMsg.Sender] = forwdMsg.Sender
+// select_peers lets the selector pick up to B peers not in excluded, the caller must hold the peers lock
+func select_peers(bcg *BlindCounterGossiper, excluded map[guid.Guid]guid.Guid) []guid.Guid {
 
-	b_neighbors := make([]guid.Guid, bcg.B)
-	b_neigh_idx := 0
+	candidates := make([]guid.Guid, 0, len(bcg.peers.Map))
 
-	bcg.peers.Mu.RLock()
 	for neigh := range bcg.peers.Map {
-		if _, present := msg_history.already_sent_peers[neigh]; !present {
-			b_neighbors[b_neigh_idx] = neigh
-			b_neigh_idx++
-			if b_neigh_idx == bcg.B {
-				break
-			}
+		if _, present := excluded[neigh]; !present {
+			candidates = append(candidates, neigh)
 		}
 	}
 
+	return bcg.selector.Select(candidates, bcg.B)
+}
+
+func new_message_history(bcg *BlindCounterGossiper, messageID guid.Guid) *messageHistory {
+	msg_history := &messageHistory{patience: bcg.F, already_sent_peers: make(map[guid.Guid]guid.Guid)}
+	bcg.history.Map[messageID] = msg_history
+	return msg_history
+}
+
+func do_gossip_forward(bcg *BlindCounterGossiper, msg_history *messageHistory, forwdMsg *MessageToForward) {
+
+	msg_history.already_sent_peers[forwdMsg.Sender] = forwdMsg.Sender
+
+	bcg.peers.Mu.RLock()
 	failedPeers := make([]guid.Guid, 0, bcg.B)
-	for i := 0; i < b_neigh_idx; i++ {
-		err := bcg.peers.Map[b_neighbors[i]].Forward(bcg.core, forwdMsg.Payload)
+	for _, neigh := range select_peers(bcg, msg_history.already_sent_peers) {
+		err := bcg.peers.Map[neigh].Forward(bcg.core, forwdMsg.Payload)
 		if err != nil {
-			failedPeers = append(failedPeers, b_neighbors[i])
+			failedPeers = append(failedPeers, neigh)
 		} else {
-			msg_history.already_sent_peers[b_neighbors[i]] = b_neighbors[i]
+			msg_history.already_sent_peers[neigh] = neigh
 		}
 	}
 	bcg.peers.Mu.RUnlock()
@@ -133,38 +214,76 @@ func do_gossip_push(bcg *BlindCounterGossiper) error {
 		return fmt.Errorf("error generating message guid, details: %s", err)
 	}
 
+	updates, err := bcg.core.GetStateUpdates()
+	if err != nil {
+		return fmt.Errorf("error getting core updates for pushing, details: %s", err)
+	}
+
 	bcg.history.Mu.Lock()
 	defer bcg.history.Mu.Unlock()
 
-	bcg.history.Map[messageID] = &messageHistory{patience: bcg.F, already_sent_peers: make(map[guid.Guid]guid.Guid)}
-	msg_history := bcg.history.Map[messageID]
+	msg_history := new_message_history(bcg, messageID)
+
+	bcg.peers.Mu.RLock()
+	failedPeers := make([]guid.Guid, 0, bcg.B)
+	for _, neigh := range select_peers(bcg, msg_history.already_sent_peers) {
+		err := bcg.peers.Map[neigh].Push(bcg.core, updates, messageID)
+		if err != nil {
+			failedPeers = append(failedPeers, neigh)
+		} else {
+			msg_history.already_sent_peers[neigh] = neigh
+		}
+	}
+	bcg.peers.Mu.RUnlock()
+
+	bcg.core.SignalFailed(failedPeers)
+
+	msg_history.patience--
 
-	b_neighbors := make([]guid.Guid, bcg.B)
-	b_neigh_idx := 0
+	return nil
+}
+
+// do_gossip_pull asks B peers for their state, pulls are not rumors so they are not tracked
+func do_gossip_pull(bcg *BlindCounterGossiper) {
 
 	bcg.peers.Mu.RLock()
-	for neigh := range bcg.peers.Map {
-		if _, present := msg_history.already_sent_peers[neigh]; !present {
-			b_neighbors[b_neigh_idx] = neigh
-			b_neigh_idx++
-			if b_neigh_idx == bcg.B {
-				break
-			}
+	failedPeers := make([]guid.Guid, 0, bcg.B)
+	for _, neigh := range select_peers(bcg, nil) {
+		if err := bcg.peers.Map[neigh].Pull(bcg.core); err != nil {
+			failedPeers = append(failedPeers, neigh)
 		}
 	}
+	bcg.peers.Mu.RUnlock()
+
+	bcg.core.SignalFailed(failedPeers)
+}
+
+// do_gossip_exchange pushes the updates to B peers, which forward them as a rumor,
+// and pulls theirs back
+func do_gossip_exchange(bcg *BlindCounterGossiper) error {
+
+	messageID, err := guid.GenerateGUID()
+	if err != nil {
+		return fmt.Errorf("error generating message guid, details: %s", err)
+	}
 
 	updates, err := bcg.core.GetStateUpdates()
 	if err != nil {
-		return fmt.Errorf("error getting core updates for pushing, details: %s", err)
+		return fmt.Errorf("error getting core updates for exchanging, details: %s", err)
 	}
 
+	bcg.history.Mu.Lock()
+	defer bcg.history.Mu.Unlock()
+
+	msg_history := new_message_history(bcg, messageID)
+
+	bcg.peers.Mu.RLock()
 	failedPeers := make([]guid.Guid, 0, bcg.B)
-	for i := 0; i < b_neigh_idx; i++ {
-		err := bcg.peers.Map[b_neighbors[i]].Push(bcg.core, updates, messageID)
-		if err != nil {
-			failedPeers = append(failedPeers, b_neighbors[i])
+	for _, neigh := range select_peers(bcg, msg_history.already_sent_peers) {
+		if err := bcg.peers.Map[neigh].Exchange(bcg.core, updates, messageID); err != nil {
+			failedPeers = append(failedPeers, neigh)
 		} else {
-			msg_history.already_sent_peers[b_neighbors[i]] = b_neighbors[i]
+			msg_history.already_sent_peers[neigh] = neigh
 		}
 	}
 	bcg.peers.Mu.RUnlock()
@@ -176,11 +295,37 @@ func do_gossip_push(bcg *BlindCounterGossiper) error {
 	return nil
 }
 
+func do_gossip_round(bcg *BlindCounterGossiper, mode GossipMode) {
+	var err error
+
+	switch mode {
+	case PushMode:
+		err = do_gossip_push(bcg)
+	case PullMode:
+		do_gossip_pull(bcg)
+	case ExchangeMode:
+		err = do_gossip_exchange(bcg)
+	}
+
+	if err != nil {
+		log.Printf("error in gossip round, details: %s\n", err)
+	}
+}
+
 func (bcg *BlindCounterGossiper) gossip_routine() {
 
 	cleaner_stopchann := make(chan bool)
 	go bcg.message_history_cleaner(&cleaner_stopchann)
 
+	//A nil channel blocks forever, so no round is started if they are disabled
+	var round_timer *time.Timer
+	var rounds <-chan time.Time
+	if bcg.roundInterval > 0 {
+		round_timer = time.NewTimer(bcg.nextRound())
+		defer round_timer.Stop()
+		rounds = round_timer.C
+	}
+
 	for {
 		select {
 		case <-bcg.stopchann:
@@ -196,60 +341,103 @@ func (bcg *BlindCounterGossiper) gossip_routine() {
 				}
 			}
 
-		case <-bcg.inputchann:
-			do_gossip_push(bcg)
-
-		default:
-			bcg.Registrations.Mu.RLock()
-			for _, v := range bcg.Registrations.Map {
-				select {
-				case data := <-v.Chann:
-
-					switch mssg := data.(type) {
-					case *MessageToForward:
-
-						bcg.history.Mu.Lock()
-
-						msg_history, ok := bcg.history.Map[mssg.MessageID]
-						if !ok {
-							bcg.history.Map[mssg.MessageID] = &messageHistory{patience: bcg.F,
-								already_sent_peers: make(map[guid.Guid]guid.Guid, 0)}
-
-							msg_history = bcg.history.Map[mssg.MessageID]
-						}
-
-						if msg_history.patience == 0 {
-							continue
-						}
-
-						do_gossip_forward(bcg, msg_history, mssg)
-
-						bcg.history.Mu.Unlock()
-					case bool:
-						if err := do_gossip_push(bcg); err != nil {
-							log.Printf("error pushing new gossip, details: %s\n", err)
-						}
-					default:
-						log.Println("message passed in bad format, skipping")
-						continue
-					}
-				default:
-					continue
-				}
+		case mode := <-bcg.inputchann:
+			do_gossip_round(bcg, mode)
+
+		case <-rounds:
+			do_gossip_round(bcg, bcg.roundMode)
+			round_timer.Reset(bcg.nextRound())
+
+		case data := <-bcg.events:
+			bcg.handle_event(data)
+		}
+	}
+}
+
+func (bcg *BlindCounterGossiper) handle_event(data any) {
+
+	switch mssg := data.(type) {
+	case *MessageToForward:
+
+		bcg.history.Mu.Lock()
+		defer bcg.history.Mu.Unlock()
+
+		msg_history, ok := bcg.history.Map[mssg.MessageID]
+		if !ok {
+			msg_history = new_message_history(bcg, mssg.MessageID)
+		}
+
+		if msg_history.patience > 0 {
+			do_gossip_forward(bcg, msg_history, mssg)
+		}
+	case bool:
+		if err := do_gossip_push(bcg); err != nil {
+			log.Printf("error pushing new gossip, details: %s\n", err)
+		}
+	default:
+		log.Println("message passed in bad format, skipping")
+	}
+}
+
+// ObserveSubject registers to subj and relays everything it pushes to the gossip loop
+func (bcg *BlindCounterGossiper) ObserveSubject(subj channelobserver.ChannelObserverSubject) {
+	bcg.ChannelObserverObserver.ObserveSubject(subj)
+
+	bcg.Registrations.Mu.RLock()
+	chann := bcg.Registrations.Map[subj].Chann
+	bcg.Registrations.Mu.RUnlock()
+
+	stopchann := make(chan bool)
+	bcg.relays.Mu.Lock()
+	bcg.relays.Map[subj] = stopchann
+	bcg.relays.Mu.Unlock()
+
+	go func() {
+		//Ends when the subject closes the channel on unregistration. The channel is always
+		//drained: a subject blocked on it would hold its lock and never unregister it
+		for data := range chann {
+			select {
+			case <-stopchann:
+				continue
+			default:
+			}
+
+			select {
+			case bcg.events <- data:
+			default:
+				//The gossip loop is stopped or lagging, a lost rumor is tolerated by the epidemic
 			}
-			bcg.Registrations.Mu.RUnlock()
 		}
+	}()
+}
+
+func (bcg *BlindCounterGossiper) UnfollowSubject(subj channelobserver.ChannelObserverSubject) bool {
+	bcg.relays.Mu.Lock()
+	if stopchann, ok := bcg.relays.Map[subj]; ok {
+		close(stopchann)
+		delete(bcg.relays.Map, subj)
 	}
+	bcg.relays.Mu.Unlock()
+
+	retVal := bcg.ChannelObserverObserver.UnfollowSubject(subj)
+
+	bcg.Registrations.Mu.Lock()
+	delete(bcg.Registrations.Map, subj)
+	bcg.Registrations.Mu.Unlock()
+
+	return retVal
 }
 
 func (bcg *BlindCounterGossiper) message_history_cleaner(stopchann *chan bool) {
 
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case <-*stopchann:
 			return
-		default:
-			time.Sleep(10 * time.Second)
+		case <-ticker.C:
 			bcg.history.Mu.Lock()
 
 			for k, v := range bcg.history.Map {
@@ -263,10 +451,74 @@ func (bcg *BlindCounterGossiper) message_history_cleaner(stopchann *chan bool) {
 	}
 }
 
-func (bcg *BlindCounterGossiper) AddPeer(_ guid.Guid, _ communication.GNCFDCommunicationChannel) {
-	log.Printf("Please add peers through LockedMap")
+// AddPeer starts gossiping with peer through channel. A channel already bound to
+// peer is replaced and released. Rounds in progress are not affected
+func (bcg *BlindCounterGossiper) AddPeer(peer guid.Guid, channel communication.GNCFDCommunicationChannel) {
+	bcg.peers.Mu.Lock()
+	old, present := bcg.peers.Map[peer]
+	bcg.peers.Map[peer] = channel
+	bcg.peers.Mu.Unlock()
+
+	if present && old != channel {
+		release_channel(peer, old)
+	}
+
+	if !present {
+		bcg.PushToChannels(&PeerEvent{Peer: peer, Kind: PeerAdded})
+	}
+}
+
+// RemovePeer stops gossiping with peer and releases its channel
+func (bcg *BlindCounterGossiper) RemovePeer(peer guid.Guid) {
+	bcg.peers.Mu.Lock()
+	channel, present := bcg.peers.Map[peer]
+	delete(bcg.peers.Map, peer)
+	bcg.peers.Mu.Unlock()
+
+	if !present {
+		return
+	}
+
+	//No round can be using the channel: they hold the read lock while sending
+	release_channel(peer, channel)
+
+	bcg.PushToChannels(&PeerEvent{Peer: peer, Kind: PeerRemoved})
+}
+
+// Peers returns the guids of the current peers
+func (bcg *BlindCounterGossiper) Peers() []guid.Guid {
+	bcg.peers.Mu.RLock()
+	defer bcg.peers.Mu.RUnlock()
+
+	retVal := make([]guid.Guid, 0, len(bcg.peers.Map))
+	for peer := range bcg.peers.Map {
+		retVal = append(retVal, peer)
+	}
+
+	return retVal
+}
+
+// PeerAddresses returns the network address of the peers reached through an addressable channel
+func (bcg *BlindCounterGossiper) PeerAddresses() map[guid.Guid]string {
+	bcg.peers.Mu.RLock()
+	defer bcg.peers.Mu.RUnlock()
+
+	retVal := make(map[guid.Guid]string, len(bcg.peers.Map))
+	for peer, channel := range bcg.peers.Map {
+		if addressable, ok := channel.(communication.AddressableChannel); ok {
+			retVal[peer] = addressable.Address()
+		}
+	}
+
+	return retVal
 }
 
-func (bcg *BlindCounterGossiper) RemovePeer(_ guid.Guid) {
-	log.Printf("Please remove peers through LockedMap")
+func release_channel(peer guid.Guid, channel communication.GNCFDCommunicationChannel) {
+	releasable, ok := channel.(communication.ReleasableChannel)
+	if !ok {
+		return
+	}
+	if err := releasable.Release(); err != nil {
+		log.Printf("error releasing channel of %v, details: %s\n", peer, err)
+	}
 }
@@ -0,0 +1,198 @@
+package gossip_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/communication"
+	"github.com/sebastianopriscan/GNCFD/communication/loopback"
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	channelobserver "github.com/sebastianopriscan/GNCFD/utils/channel_observer"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+	lockedmap "github.com/sebastianopriscan/GNCFD/utils/locked_map"
+)
+
+type testNode struct {
+	id       guid.Guid
+	core     *vivaldi.VivaldiCore[float64]
+	peers    *lockedmap.LockedMap[guid.Guid, communication.GNCFDCommunicationChannel]
+	gossiper *gossip.BlindCounterGossiper
+}
+
+func newTestNodes(t *testing.T, count int) []*testNode {
+	space, err := nvs.NewRealEuclideanSpace(2)
+	if err != nil {
+		t.Fatalf("Unable to create space, details: %s", err)
+	}
+
+	network := loopback.NewNetwork(loopback.LinkConfig{Rtt: time.Millisecond}, 1)
+
+	nodes := make([]*testNode, count)
+	for i := range nodes {
+		id := guid.Guid{byte(i + 1)}
+		cr, err := vivaldi.NewVivaldiCore(id, []float64{0., 0.}, space, 0.25, 0.25)
+		if err != nil {
+			t.Fatalf("Unable to create core, details: %s", err)
+		}
+		peers := &lockedmap.LockedMap[guid.Guid, communication.GNCFDCommunicationChannel]{
+			Map: make(map[guid.Guid]communication.GNCFDCommunicationChannel),
+		}
+		nodes[i] = &testNode{id: id, core: cr, peers: peers}
+		nodes[i].gossiper = gossip.NewBlindCounterGossiper(peers, cr, 2, 1)
+
+		node, err := network.AddNode(id, cr)
+		if err != nil {
+			t.Fatalf("Unable to add node, details: %s", err)
+		}
+		nodes[i].gossiper.ObserveSubject(node)
+	}
+
+	for _, from := range nodes {
+		for _, to := range nodes {
+			if from != to {
+				from.peers.Map[to.id] = network.NewChannel(from.id, to.id)
+			}
+		}
+	}
+
+	return nodes
+}
+
+func waitKnown(t *testing.T, observer *testNode, observed guid.Guid) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if closest, _ := observer.core.GetClosestOf([]guid.Guid{observed}); len(closest) == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Node %v never learnt about node %v", observer.id, observed)
+}
+
+func TestPeriodicRounds(t *testing.T) {
+	for _, mode := range []gossip.GossipMode{gossip.PushMode, gossip.PullMode, gossip.ExchangeMode} {
+		nodes := newTestNodes(t, 2)
+
+		if err := nodes[0].gossiper.SetGossipRound(20*time.Millisecond, 5*time.Millisecond, mode); err != nil {
+			t.Fatalf("Unable to set gossip rounds, details: %s", err)
+		}
+		nodes[0].gossiper.StartGossiping()
+
+		if mode == gossip.PullMode {
+			waitKnown(t, nodes[0], nodes[1].id)
+		} else {
+			waitKnown(t, nodes[1], nodes[0].id)
+		}
+
+		nodes[0].gossiper.StopGossiping()
+	}
+}
+
+func TestExchangeRound(t *testing.T) {
+	nodes := newTestNodes(t, 4)
+	for _, node := range nodes {
+		node.gossiper.StartGossiping()
+		defer node.gossiper.StopGossiping()
+	}
+
+	if !nodes[0].gossiper.InsertGossipRound(gossip.ExchangeMode) {
+		t.Fatalf("Round not inserted on a running gossiper")
+	}
+
+	//The pushed half is forwarded like a rumor, the pulled half comes back
+	for _, node := range nodes[1:] {
+		waitKnown(t, node, nodes[0].id)
+	}
+	known := 0
+	for _, node := range nodes[1:] {
+		if closest, _ := nodes[0].core.GetClosestOf([]guid.Guid{node.id}); len(closest) == 1 {
+			known++
+		}
+	}
+	if known < 2 {
+		t.Fatalf("Exchange should pull the state of B peers, %d known", known)
+	}
+}
+
+type releasingChannel struct {
+	communication.GNCFDCommunicationChannel
+	released int
+}
+
+func (rc *releasingChannel) Release() error {
+	rc.released++
+	return nil
+}
+
+func TestPeerManagement(t *testing.T) {
+	nodes := newTestNodes(t, 2)
+	gossiper := nodes[0].gossiper
+
+	events := make(chan any, 10)
+	gossiper.RegisterChannel(events)
+
+	nodes[0].peers.Mu.Lock()
+	channel := &releasingChannel{GNCFDCommunicationChannel: nodes[0].peers.Map[nodes[1].id]}
+	nodes[0].peers.Mu.Unlock()
+
+	gossiper.RemovePeer(nodes[1].id)
+	gossiper.AddPeer(nodes[1].id, channel)
+	if peers := gossiper.Peers(); len(peers) != 1 || peers[0] != nodes[1].id {
+		t.Fatalf("Unexpected peers after add: %v", peers)
+	}
+
+	gossiper.RemovePeer(nodes[1].id)
+	if len(gossiper.Peers()) != 0 {
+		t.Fatalf("Peer not removed")
+	}
+	if channel.released != 1 {
+		t.Fatalf("Channel should be released once on removal, released %d times", channel.released)
+	}
+
+	kinds := make(map[gossip.PeerEventKind]int)
+	for i := 0; i < 3; i++ {
+		select {
+		case data := <-events:
+			event := data.(*gossip.PeerEvent)
+			if event.Peer != nodes[1].id {
+				t.Fatalf("Event about unexpected peer %v", event.Peer)
+			}
+			kinds[event.Kind]++
+		case <-time.After(time.Second):
+			t.Fatalf("Missing membership event")
+		}
+	}
+	if kinds[gossip.PeerAdded] != 1 || kinds[gossip.PeerRemoved] != 2 {
+		t.Fatalf("Unexpected membership events: %v", kinds)
+	}
+}
+
+func TestUnfollowStoppedGossiper(t *testing.T) {
+	nodes := newTestNodes(t, 1)
+	gossiper := nodes[0].gossiper
+
+	subject := channelobserver.NewChannelObserverSubjectImpl()
+	gossiper.ObserveSubject(&subject)
+
+	//Nobody consumes the events of a gossiper that never started
+	for i := 0; i < 50; i++ {
+		subject.PushToChannels(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan bool)
+	go func() {
+		done <- gossiper.UnfollowSubject(&subject)
+	}()
+
+	select {
+	case ok := <-done:
+		if !ok {
+			t.Fatalf("Subject not unfollowed")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Unfollowing a subject with pending events blocked")
+	}
+}
@@ -13,3 +13,16 @@ type GNCFDGossiper interface {
 	AddPeer(guid.Guid, communication.GNCFDCommunicationChannel)
 	RemovePeer(guid.Guid)
 }
+
+type PeerEventKind int
+
+const (
+	PeerAdded PeerEventKind = iota
+	PeerRemoved
+)
+
+// PeerEvent is pushed to the observers of a gossiper when its membership changes
+type PeerEvent struct {
+	Peer guid.Guid
+	Kind PeerEventKind
+}
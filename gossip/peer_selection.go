@@ -0,0 +1,132 @@
+package gossip
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/sebastianopriscan/GNCFD/core"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// PeerSelector chooses the fan-out of every gossip round among the eligible peers
+type PeerSelector interface {
+	// Select returns up to count distinct peers among candidates, which it may reorder
+	Select(candidates []guid.Guid, count int) []guid.Guid
+}
+
+// RandomSelector picks the peers uniformly at random
+type RandomSelector struct {
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+func NewRandomSelector(seed int64) *RandomSelector {
+	return &RandomSelector{random: rand.New(rand.NewSource(seed))}
+}
+
+func (rs *RandomSelector) Select(candidates []guid.Guid, count int) []guid.Guid {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	return shuffle_prefix(rs.random, candidates, count)
+}
+
+// shuffle_prefix moves count random candidates to the front and returns them
+func shuffle_prefix(random *rand.Rand, candidates []guid.Guid, count int) []guid.Guid {
+	count = min(count, len(candidates))
+	for i := 0; i < count; i++ {
+		j := i + random.Intn(len(candidates)-i)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+	return candidates[:count]
+}
+
+// RoundRobinSelector cycles through the peers in guid order, resuming after the
+// last one selected so that every peer is eventually contacted
+type RoundRobinSelector struct {
+	mu   sync.Mutex
+	last guid.Guid
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{}
+}
+
+func (rrs *RoundRobinSelector) Select(candidates []guid.Guid, count int) []guid.Guid {
+	rrs.mu.Lock()
+	defer rrs.mu.Unlock()
+
+	count = min(count, len(candidates))
+	if count == 0 {
+		return candidates[:0]
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return bytes.Compare(candidates[i][:], candidates[j][:]) < 0
+	})
+	start := sort.Search(len(candidates), func(i int) bool {
+		return bytes.Compare(candidates[i][:], rrs.last[:]) > 0
+	})
+
+	retVal := make([]guid.Guid, count)
+	for i := range retVal {
+		retVal[i] = candidates[(start+i)%len(candidates)]
+	}
+	rrs.last = retVal[count-1]
+
+	return retVal
+}
+
+// ProximitySelector mixes the peers closest in the coordinate space with random far
+// ones, as suggested by the Vivaldi authors: near peers refine the local error, far
+// peers keep the global structure of the coordinates
+type ProximitySelector struct {
+	core         core.GNCFDCore
+	nearFraction float64
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+// NewProximitySelector selects ceil(nearFraction*count) near peers, the remaining ones at random
+func NewProximitySelector(nodeCore core.GNCFDCore, nearFraction float64, seed int64) *ProximitySelector {
+	return &ProximitySelector{
+		core:         nodeCore,
+		nearFraction: max(0., min(nearFraction, 1.)),
+		random:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (ps *ProximitySelector) Select(candidates []guid.Guid, count int) []guid.Guid {
+	count = min(count, len(candidates))
+	near := int(math.Ceil(ps.nearFraction * float64(count)))
+
+	retVal := make([]guid.Guid, 0, count)
+	remaining := append(make([]guid.Guid, 0, len(candidates)), candidates...)
+
+	//Candidates never heard of by the core are not returned, hence they count as far
+	if nearest, err := ps.core.GetKNearest(remaining, near, core.QueryOptions{}); err == nil {
+		for _, peer := range nearest {
+			retVal = append(retVal, peer.Guid)
+			remaining = remove_guid(remaining, peer.Guid)
+		}
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	return append(retVal, shuffle_prefix(ps.random, remaining, count-len(retVal))...)
+}
+
+func remove_guid(guids []guid.Guid, removed guid.Guid) []guid.Guid {
+	for i, g := range guids {
+		if g == removed {
+			guids[i] = guids[len(guids)-1]
+			return guids[:len(guids)-1]
+		}
+	}
+	return guids
+}
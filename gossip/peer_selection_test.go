@@ -0,0 +1,144 @@
+package gossip_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/sebastianopriscan/GNCFD/core"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+func testGuids(count int) []guid.Guid {
+	retVal := make([]guid.Guid, count)
+	for i := range retVal {
+		retVal[i] = guid.Guid{byte(i + 1)}
+	}
+	return retVal
+}
+
+// distanceCore knows the distance of every peer from itself
+type distanceCore map[guid.Guid]float64
+
+func (dc distanceCore) GetClosestOf(guids []guid.Guid) ([]guid.Guid, error) {
+	var retVal []guid.Guid
+	best := -1.
+	for _, g := range guids {
+		distance, ok := dc[g]
+		if !ok {
+			continue
+		}
+		if best < 0 || distance < best {
+			retVal, best = []guid.Guid{g}, distance
+		} else if distance == best {
+			retVal = append(retVal, g)
+		}
+	}
+	return retVal, nil
+}
+
+func (dc distanceCore) GetIsFailed(guid.Guid) bool {
+	return false
+}
+
+func (dc distanceCore) GetSortedByDistance(guids []guid.Guid, _ core.QueryOptions) ([]core.NodeDistance, error) {
+	var retVal []core.NodeDistance
+	for _, g := range guids {
+		if distance, ok := dc[g]; ok {
+			retVal = append(retVal, core.NodeDistance{Guid: g, RTT: distance})
+		}
+	}
+	sort.Slice(retVal, func(i, j int) bool { return retVal[i].RTT < retVal[j].RTT })
+	return retVal, nil
+}
+
+func (dc distanceCore) GetKNearest(guids []guid.Guid, k int, opts core.QueryOptions) ([]core.NodeDistance, error) {
+	sorted, _ := dc.GetSortedByDistance(guids, opts)
+	return sorted[:min(k, len(sorted))], nil
+}
+
+func (dc distanceCore) GetWithinRadius(guids []guid.Guid, radius float64, opts core.QueryOptions) ([]core.NodeDistance, error) {
+	sorted, _ := dc.GetSortedByDistance(guids, opts)
+	for i := range sorted {
+		if sorted[i].RTT > radius {
+			return sorted[:i], nil
+		}
+	}
+	return sorted, nil
+}
+
+func TestRandomSelector(t *testing.T) {
+	selector := gossip.NewRandomSelector(1)
+	peers := testGuids(10)
+
+	counts := make(map[guid.Guid]int)
+	for i := 0; i < 1000; i++ {
+		selected := selector.Select(append([]guid.Guid{}, peers...), 3)
+		if len(selected) != 3 {
+			t.Fatalf("Expected 3 peers, got %d", len(selected))
+		}
+		seen := make(map[guid.Guid]bool)
+		for _, peer := range selected {
+			if seen[peer] {
+				t.Fatalf("Peer %v selected twice", peer)
+			}
+			seen[peer] = true
+			counts[peer]++
+		}
+	}
+
+	for _, peer := range peers {
+		if counts[peer] < 200 || counts[peer] > 400 {
+			t.Fatalf("Selection is not uniform, peer %v selected %d times out of 300 expected", peer, counts[peer])
+		}
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	selector := gossip.NewRoundRobinSelector()
+	peers := testGuids(5)
+
+	seen := make(map[guid.Guid]int)
+	for i := 0; i < 5; i++ {
+		for _, peer := range selector.Select(append([]guid.Guid{}, peers...), 2) {
+			seen[peer]++
+		}
+	}
+
+	for _, peer := range peers {
+		if seen[peer] != 2 {
+			t.Fatalf("Peer %v selected %d times, 2 expected", peer, seen[peer])
+		}
+	}
+}
+
+func TestProximitySelector(t *testing.T) {
+	peers := testGuids(10)
+	distances := distanceCore{}
+	for i, peer := range peers {
+		distances[peer] = float64(i)
+	}
+
+	selector := gossip.NewProximitySelector(distances, 0.5, 1)
+
+	farSeen := make(map[guid.Guid]bool)
+	for i := 0; i < 50; i++ {
+		selected := selector.Select(append([]guid.Guid{}, peers...), 4)
+		if len(selected) != 4 {
+			t.Fatalf("Expected 4 peers, got %d", len(selected))
+		}
+		if selected[0] != peers[0] || selected[1] != peers[1] {
+			t.Fatalf("The two closest peers should come first, got %v", selected[:2])
+		}
+		for _, peer := range selected[2:] {
+			if peer == peers[0] || peer == peers[1] {
+				t.Fatalf("Peer %v selected twice", peer)
+			}
+			farSeen[peer] = true
+		}
+	}
+
+	if len(farSeen) < 6 {
+		t.Fatalf("Far peers should be random, only %d distinct seen", len(farSeen))
+	}
+}
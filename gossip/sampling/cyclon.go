@@ -0,0 +1,291 @@
+// Package sampling implements the Cyclon peer sampling protocol: every node keeps a
+// bounded partial view of the system, refreshed by periodically shuffling a part of
+// it with the oldest neighbor. The view is handed to a gossiper as its peer set, so
+// that no node needs to know the whole membership
+package sampling
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/communication"
+	"github.com/sebastianopriscan/GNCFD/gossip"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// Descriptor identifies a node in a view, Age counts the shuffles since it was created
+type Descriptor struct {
+	Guid    guid.Guid
+	Address string
+	Age     int
+}
+
+// ShuffleTransport carries a shuffle to target, returning the entries it sent back
+type ShuffleTransport interface {
+	Shuffle(target Descriptor, sent []Descriptor) ([]Descriptor, error)
+}
+
+// Dialer opens the gossip channel towards a node entering the view
+type Dialer func(Descriptor) (communication.GNCFDCommunicationChannel, error)
+
+type Cyclon struct {
+	self          Descriptor
+	viewSize      int
+	shuffleLength int
+	transport     ShuffleTransport
+
+	mu     sync.Mutex
+	view   []Descriptor
+	random *rand.Rand
+
+	feed_mu  sync.Mutex
+	gossiper gossip.GNCFDGossiper
+	dial     Dialer
+	fed      map[guid.Guid]bool
+
+	stopchann chan bool
+}
+
+// NewCyclon creates a node advertising self, keeping up to viewSize neighbors and
+// exchanging shuffleLength of them at every shuffle
+func NewCyclon(self Descriptor, viewSize int, shuffleLength int, transport ShuffleTransport) (*Cyclon, error) {
+	if viewSize <= 0 || shuffleLength <= 0 || shuffleLength > viewSize {
+		return nil, errors.New("view size and shuffle length should be positive, shuffle length at most the view size")
+	}
+	if transport == nil {
+		return nil, errors.New("transport should not be nil")
+	}
+
+	return &Cyclon{
+		self:          Descriptor{Guid: self.Guid, Address: self.Address},
+		viewSize:      viewSize,
+		shuffleLength: shuffleLength,
+		transport:     transport,
+		view:          make([]Descriptor, 0, viewSize),
+		random:        rand.New(rand.NewSource(time.Now().UnixNano())),
+		fed:           make(map[guid.Guid]bool),
+	}, nil
+}
+
+// FeedGossiper keeps the peers of gossiper equal to the view, dialing the nodes
+// entering it and removing the ones leaving it
+func (cl *Cyclon) FeedGossiper(gossiper gossip.GNCFDGossiper, dial Dialer) {
+	cl.feed_mu.Lock()
+	cl.gossiper = gossiper
+	cl.dial = dial
+	cl.feed_mu.Unlock()
+
+	cl.sync_gossiper()
+}
+
+// AddSeeds inserts the contact nodes into the view while there is room for them
+func (cl *Cyclon) AddSeeds(seeds ...Descriptor) {
+	cl.mu.Lock()
+	for _, seed := range seeds {
+		if len(cl.view) == cl.viewSize {
+			break
+		}
+		if seed.Guid != cl.self.Guid && cl.find(seed.Guid) < 0 {
+			cl.view = append(cl.view, Descriptor{Guid: seed.Guid, Address: seed.Address})
+		}
+	}
+	cl.mu.Unlock()
+
+	cl.sync_gossiper()
+}
+
+// RemovePeer drops peer from the view, e.g. because it left the system
+func (cl *Cyclon) RemovePeer(peer guid.Guid) {
+	cl.mu.Lock()
+	if idx := cl.find(peer); idx >= 0 {
+		cl.remove(idx)
+	}
+	cl.mu.Unlock()
+
+	cl.sync_gossiper()
+}
+
+// View returns a copy of the current view
+func (cl *Cyclon) View() []Descriptor {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	return append(make([]Descriptor, 0, len(cl.view)), cl.view...)
+}
+
+// Shuffle runs the active side of a shuffle with the oldest neighbor, which
+// leaves the view: it comes back only if it answers or is advertised again
+func (cl *Cyclon) Shuffle() error {
+	cl.mu.Lock()
+	if len(cl.view) == 0 {
+		cl.mu.Unlock()
+		return nil
+	}
+
+	oldest := 0
+	for i := range cl.view {
+		cl.view[i].Age++
+		if cl.view[i].Age > cl.view[oldest].Age {
+			oldest = i
+		}
+	}
+	target := cl.view[oldest]
+	cl.remove(oldest)
+
+	sent := cl.random_subset(cl.shuffleLength - 1)
+	cl.mu.Unlock()
+
+	received, err := cl.transport.Shuffle(target, append(sent, cl.self))
+	if err != nil {
+		cl.sync_gossiper()
+		return fmt.Errorf("error shuffling with %v, details: %s", target.Guid, err)
+	}
+
+	cl.mu.Lock()
+	cl.merge(received, sent)
+	cl.mu.Unlock()
+
+	cl.sync_gossiper()
+
+	return nil
+}
+
+// HandleShuffle runs the passive side of a shuffle started by another node,
+// returning the entries to send back
+func (cl *Cyclon) HandleShuffle(received []Descriptor) []Descriptor {
+	cl.mu.Lock()
+	reply := cl.random_subset(cl.shuffleLength)
+	cl.merge(received, reply)
+	cl.mu.Unlock()
+
+	cl.sync_gossiper()
+
+	return reply
+}
+
+// StartSampling shuffles every interval until StopSampling is called
+func (cl *Cyclon) StartSampling(interval time.Duration) bool {
+	if cl.stopchann != nil || interval <= 0 {
+		return false
+	}
+
+	cl.stopchann = make(chan bool)
+	go cl.sampling_routine(interval, cl.stopchann)
+
+	return true
+}
+
+func (cl *Cyclon) StopSampling() {
+	if cl.stopchann == nil {
+		return
+	}
+
+	cl.stopchann <- true
+	close(cl.stopchann)
+	cl.stopchann = nil
+}
+
+func (cl *Cyclon) sampling_routine(interval time.Duration, stopchann chan bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopchann:
+			return
+		case <-ticker.C:
+			if err := cl.Shuffle(); err != nil {
+				log.Printf("error in shuffle, details: %s\n", err)
+			}
+		}
+	}
+}
+
+// find returns the position of peer in the view, -1 if absent. The caller must hold the lock
+func (cl *Cyclon) find(peer guid.Guid) int {
+	for i := range cl.view {
+		if cl.view[i].Guid == peer {
+			return i
+		}
+	}
+	return -1
+}
+
+func (cl *Cyclon) remove(idx int) {
+	cl.view[idx] = cl.view[len(cl.view)-1]
+	cl.view = cl.view[:len(cl.view)-1]
+}
+
+// random_subset copies up to count random entries of the view, the caller must hold the lock
+func (cl *Cyclon) random_subset(count int) []Descriptor {
+	count = min(count, len(cl.view))
+	retVal := make([]Descriptor, 0, count)
+	for _, idx := range cl.random.Perm(len(cl.view))[:count] {
+		retVal = append(retVal, cl.view[idx])
+	}
+	return retVal
+}
+
+// merge inserts the received entries, using the empty slots first and then the
+// slots of the entries sent away. The caller must hold the lock
+func (cl *Cyclon) merge(received []Descriptor, sent []Descriptor) {
+	for _, entry := range received {
+		if entry.Guid == cl.self.Guid || cl.find(entry.Guid) >= 0 {
+			continue
+		}
+
+		if len(cl.view) < cl.viewSize {
+			cl.view = append(cl.view, entry)
+			continue
+		}
+
+		for len(sent) > 0 {
+			idx := cl.find(sent[0].Guid)
+			sent = sent[1:]
+			if idx >= 0 {
+				cl.view[idx] = entry
+				break
+			}
+		}
+	}
+}
+
+// sync_gossiper aligns the peers of the gossiper with the view
+func (cl *Cyclon) sync_gossiper() {
+	cl.feed_mu.Lock()
+	defer cl.feed_mu.Unlock()
+
+	if cl.gossiper == nil {
+		return
+	}
+
+	view := cl.View()
+	current := make(map[guid.Guid]bool, len(view))
+	for _, entry := range view {
+		current[entry.Guid] = true
+	}
+
+	for peer := range cl.fed {
+		if !current[peer] {
+			cl.gossiper.RemovePeer(peer)
+			delete(cl.fed, peer)
+		}
+	}
+
+	for _, entry := range view {
+		if cl.fed[entry.Guid] {
+			continue
+		}
+		channel, err := cl.dial(entry)
+		if err != nil {
+			log.Printf("error dialing %v, details: %s\n", entry.Guid, err)
+			continue
+		}
+		cl.gossiper.AddPeer(entry.Guid, channel)
+		cl.fed[entry.Guid] = true
+	}
+}
@@ -0,0 +1,153 @@
+package sampling
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sebastianopriscan/GNCFD/communication"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+// memoryTransport delivers the shuffles directly to the other nodes
+type memoryTransport struct {
+	nodes map[guid.Guid]*Cyclon
+	down  map[guid.Guid]bool
+}
+
+func (mt *memoryTransport) Shuffle(target Descriptor, sent []Descriptor) ([]Descriptor, error) {
+	node, ok := mt.nodes[target.Guid]
+	if !ok || mt.down[target.Guid] {
+		return nil, errors.New("unreachable")
+	}
+	return node.HandleShuffle(sent), nil
+}
+
+// peerRecorder is a gossiper only tracking its peers
+type peerRecorder struct {
+	mu    sync.Mutex
+	peers map[guid.Guid]communication.GNCFDCommunicationChannel
+}
+
+func (pr *peerRecorder) StartGossiping() bool { return true }
+func (pr *peerRecorder) StopGossiping()       {}
+func (pr *peerRecorder) InsertGossip() bool   { return true }
+
+func (pr *peerRecorder) AddPeer(peer guid.Guid, channel communication.GNCFDCommunicationChannel) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.peers[peer] = channel
+}
+
+func (pr *peerRecorder) RemovePeer(peer guid.Guid) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	delete(pr.peers, peer)
+}
+
+func newTestOverlay(t *testing.T, count int, viewSize int, shuffleLength int) ([]*Cyclon, *memoryTransport) {
+	transport := &memoryTransport{nodes: make(map[guid.Guid]*Cyclon), down: make(map[guid.Guid]bool)}
+
+	nodes := make([]*Cyclon, count)
+	for i := range nodes {
+		var err error
+		nodes[i], err = NewCyclon(Descriptor{Guid: guid.Guid{byte(i >> 8), byte(i), 1}}, viewSize, shuffleLength, transport)
+		if err != nil {
+			t.Fatalf("Unable to create node, details: %s", err)
+		}
+		transport.nodes[nodes[i].self.Guid] = nodes[i]
+	}
+
+	//Every node only knows the next one at the beginning
+	for i, node := range nodes {
+		node.AddSeeds(nodes[(i+1)%count].self)
+	}
+
+	return nodes, transport
+}
+
+func TestViewsConverge(t *testing.T) {
+	nodes, _ := newTestOverlay(t, 200, 8, 4)
+
+	for round := 0; round < 50; round++ {
+		for _, node := range nodes {
+			if err := node.Shuffle(); err != nil {
+				t.Fatalf("Shuffle failed, details: %s", err)
+			}
+		}
+	}
+
+	inDegree := make(map[guid.Guid]int)
+	for _, node := range nodes {
+		view := node.View()
+		if len(view) != 8 {
+			t.Fatalf("View of %v has %d entries, 8 expected", node.self.Guid, len(view))
+		}
+		seen := make(map[guid.Guid]bool)
+		for _, entry := range view {
+			if entry.Guid == node.self.Guid || seen[entry.Guid] {
+				t.Fatalf("View of %v contains itself or duplicates", node.self.Guid)
+			}
+			seen[entry.Guid] = true
+			inDegree[entry.Guid]++
+		}
+	}
+
+	if len(inDegree) != len(nodes) {
+		t.Fatalf("%d nodes are not in any view", len(nodes)-len(inDegree))
+	}
+}
+
+func TestDeadNodesLeaveViews(t *testing.T) {
+	nodes, transport := newTestOverlay(t, 50, 6, 3)
+
+	for round := 0; round < 20; round++ {
+		for _, node := range nodes {
+			node.Shuffle()
+		}
+	}
+
+	dead := nodes[0].self.Guid
+	transport.down[dead] = true
+
+	for round := 0; round < 30; round++ {
+		for _, node := range nodes[1:] {
+			node.Shuffle()
+		}
+	}
+
+	for _, node := range nodes[1:] {
+		for _, entry := range node.View() {
+			if entry.Guid == dead {
+				t.Fatalf("Dead node still in the view of %v", node.self.Guid)
+			}
+		}
+	}
+}
+
+func TestFeedGossiper(t *testing.T) {
+	nodes, _ := newTestOverlay(t, 30, 5, 3)
+
+	recorder := &peerRecorder{peers: make(map[guid.Guid]communication.GNCFDCommunicationChannel)}
+	nodes[0].FeedGossiper(recorder, func(Descriptor) (communication.GNCFDCommunicationChannel, error) {
+		return nil, nil
+	})
+
+	for round := 0; round < 10; round++ {
+		for _, node := range nodes {
+			node.Shuffle()
+		}
+	}
+
+	view := nodes[0].View()
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if len(recorder.peers) != len(view) {
+		t.Fatalf("Gossiper has %d peers, view has %d entries", len(recorder.peers), len(view))
+	}
+	for _, entry := range view {
+		if _, ok := recorder.peers[entry.Guid]; !ok {
+			t.Fatalf("View entry %v not fed to the gossiper", entry.Guid)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package simulator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LatencyMatrix holds all-pairs round trip times, missing measurements are NaN
+type LatencyMatrix [][]float64
+
+func (lm LatencyMatrix) Size() int {
+	return len(lm)
+}
+
+// Rtt returns the round trip time between i and j, false if it was not measured
+func (lm LatencyMatrix) Rtt(i int, j int) (float64, bool) {
+	rtt := lm[i][j]
+	if math.IsNaN(rtt) || rtt <= 0 {
+		return 0., false
+	}
+	return rtt, true
+}
+
+func (lm LatencyMatrix) validate() error {
+	if len(lm) < 2 {
+		return errors.New("the matrix should contain at least 2 nodes")
+	}
+	for i, row := range lm {
+		if len(row) != len(lm) {
+			return fmt.Errorf("row %d has %d entries, %d expected", i, len(row), len(lm))
+		}
+	}
+	return nil
+}
+
+// ReadMatrix parses a square whitespace separated matrix, as the King and
+// PlanetLab all-pairs datasets are distributed. Negative entries mark missing
+// measurements and every value is multiplied by scale (e.g. 0.001 to turn the
+// King microseconds into milliseconds)
+func ReadMatrix(reader io.Reader, scale float64) (LatencyMatrix, error) {
+	matrix := make(LatencyMatrix, 0)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		row := make([]float64, len(fields))
+		for i, field := range fields {
+			value, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing line %d, details: %s", line, err)
+			}
+			row[i] = asRtt(value, scale)
+		}
+		matrix = append(matrix, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading matrix, details: %s", err)
+	}
+
+	if err := matrix.validate(); err != nil {
+		return nil, fmt.Errorf("malformed matrix, details: %s", err)
+	}
+
+	return matrix, nil
+}
+
+// ReadTriplets parses "i j rtt" lines with zero based node indexes, pairs not
+// listed are missing. If only one direction of a pair is given it is used for both
+func ReadTriplets(reader io.Reader, scale float64) (LatencyMatrix, error) {
+	type triplet struct {
+		i, j int
+		rtt  float64
+	}
+
+	triplets := make([]triplet, 0)
+	size := 0
+
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d should contain 3 fields", line)
+		}
+		i, err := strconv.Atoi(fields[0])
+		if err != nil || i < 0 {
+			return nil, fmt.Errorf("bad node index at line %d", line)
+		}
+		j, err := strconv.Atoi(fields[1])
+		if err != nil || j < 0 {
+			return nil, fmt.Errorf("bad node index at line %d", line)
+		}
+		value, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing line %d, details: %s", line, err)
+		}
+
+		triplets = append(triplets, triplet{i: i, j: j, rtt: asRtt(value, scale)})
+		size = max(size, i+1, j+1)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading triplets, details: %s", err)
+	}
+
+	matrix := make(LatencyMatrix, size)
+	for i := range matrix {
+		matrix[i] = make([]float64, size)
+		for j := range matrix[i] {
+			matrix[i][j] = math.NaN()
+		}
+	}
+	for _, t := range triplets {
+		matrix[t.i][t.j] = t.rtt
+		if math.IsNaN(matrix[t.j][t.i]) {
+			matrix[t.j][t.i] = t.rtt
+		}
+	}
+
+	if err := matrix.validate(); err != nil {
+		return nil, fmt.Errorf("malformed matrix, details: %s", err)
+	}
+
+	return matrix, nil
+}
+
+func asRtt(value float64, scale float64) float64 {
+	if value < 0 {
+		return math.NaN()
+	}
+	return value * scale
+}
@@ -0,0 +1,318 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/sebastianopriscan/GNCFD/core/impl/vivaldi"
+	"github.com/sebastianopriscan/GNCFD/core/nvs"
+	"github.com/sebastianopriscan/GNCFD/utils/guid"
+)
+
+const DefaultConvergenceThreshold = 0.1
+
+// Config mirrors the knobs of VivaldiCore and BlindCounterGossiper, so that
+// they can be tuned before being deployed
+type Config struct {
+	Dimension int
+	// Optional: constructor of the coordinate space, NewRealEuclideanSpace if nil
+	Space func(int) (*nvs.NormedVectorSpace[float64], error)
+
+	Ce float64
+	Cc float64
+
+	B int
+	F int
+
+	Rounds        int
+	RoundDuration time.Duration
+	// Median relative error below which the system is considered converged
+	ConvergenceThreshold float64
+
+	Seed int64
+}
+
+type CDFPoint struct {
+	RelativeError float64
+	Fraction      float64
+}
+
+type RoundStats struct {
+	Round    int
+	Time     time.Duration
+	Messages int
+
+	MedianError float64
+	P90Error    float64
+	CDF         []CDFPoint
+}
+
+type Report struct {
+	Rounds []RoundStats
+
+	Converged        bool
+	ConvergenceRound int
+	ConvergenceTime  time.Duration
+}
+
+type messageHistory struct {
+	patience int
+	sent     map[int]bool
+}
+
+type delivery struct {
+	messageID int
+	from      int
+	to        int
+	data      *vivaldi.VivaldiMetadata[float64]
+}
+
+// Simulator runs one VivaldiCore per node of a latency matrix in virtual time,
+// disseminating the updates with the blind counter rumor mongering scheme.
+// Runs are reproducible given the seed
+type Simulator struct {
+	config Config
+	matrix LatencyMatrix
+
+	space *nvs.NormedVectorSpace[float64]
+	cores []*vivaldi.VivaldiCore[float64]
+
+	rand *rand.Rand
+
+	start   time.Time
+	now     time.Time
+	round   int
+	lastMsg int
+}
+
+func NewSimulator(matrix LatencyMatrix, config Config) (*Simulator, error) {
+	if err := matrix.validate(); err != nil {
+		return nil, fmt.Errorf("malformed matrix, details: %s", err)
+	}
+	if config.Dimension <= 0 || config.B <= 0 || config.F <= 0 || config.Rounds <= 0 {
+		return nil, errors.New("dimension, B, F and rounds should be greater than 0")
+	}
+	if config.RoundDuration <= 0 {
+		config.RoundDuration = time.Second
+	}
+	if config.ConvergenceThreshold <= 0 {
+		config.ConvergenceThreshold = DefaultConvergenceThreshold
+	}
+	if config.Space == nil {
+		config.Space = nvs.NewRealEuclideanSpace
+	}
+
+	space, err := config.Space(config.Dimension)
+	if err != nil {
+		return nil, fmt.Errorf("error creating space, details: %s", err)
+	}
+
+	sim := &Simulator{
+		config: config,
+		matrix: matrix,
+		space:  space,
+		cores:  make([]*vivaldi.VivaldiCore[float64], matrix.Size()),
+		rand:   rand.New(rand.NewSource(config.Seed)),
+		start:  time.Unix(0, 0),
+	}
+	sim.now = sim.start
+
+	space.SetRandomEl(sim.rand.ExpFloat64)
+
+	for i := range sim.cores {
+		sim.cores[i], err = vivaldi.NewVivaldiCore(nodeGuid(i), make([]float64, space.Dimension()), space, config.Ce, config.Cc)
+		if err != nil {
+			return nil, fmt.Errorf("error creating core %d, details: %s", i, err)
+		}
+		sim.cores[i].SetClock(sim.clock)
+	}
+
+	return sim, nil
+}
+
+func nodeGuid(i int) guid.Guid {
+	return guid.Guid{byte(i >> 24), byte(i >> 16), byte(i >> 8), byte(i), 0x5e}
+}
+
+func (sim *Simulator) clock() time.Time {
+	return sim.now
+}
+
+// Core returns the core simulating the i-th node of the matrix
+func (sim *Simulator) Core(i int) *vivaldi.VivaldiCore[float64] {
+	return sim.cores[i]
+}
+
+func (sim *Simulator) Run() (*Report, error) {
+	report := &Report{Rounds: make([]RoundStats, 0, sim.config.Rounds)}
+
+	for r := 0; r < sim.config.Rounds; r++ {
+		stats, err := sim.Step()
+		if err != nil {
+			return nil, fmt.Errorf("error in round %d, details: %s", r, err)
+		}
+		report.Rounds = append(report.Rounds, stats)
+
+		if !report.Converged && stats.MedianError <= sim.config.ConvergenceThreshold {
+			report.Converged = true
+			report.ConvergenceRound = stats.Round
+			report.ConvergenceTime = stats.Time
+		}
+	}
+
+	return report, nil
+}
+
+// Step runs a single gossip round: every node pushes its updates to B peers and
+// every receiver forwards each message at most F times
+func (sim *Simulator) Step() (RoundStats, error) {
+	sim.round++
+	sim.now = sim.now.Add(sim.config.RoundDuration)
+
+	histories := make([]map[int]*messageHistory, len(sim.cores))
+	for i := range histories {
+		histories[i] = make(map[int]*messageHistory)
+	}
+
+	queue := make([]delivery, 0)
+
+	for i, cr := range sim.cores {
+		updates, err := cr.GetStateUpdates()
+		if err != nil {
+			return RoundStats{}, fmt.Errorf("error getting updates of node %d, details: %s", i, err)
+		}
+
+		sim.lastMsg++
+		history := &messageHistory{patience: sim.config.F, sent: make(map[int]bool)}
+		histories[i][sim.lastMsg] = history
+
+		for _, peer := range sim.selectPeers(i, history.sent) {
+			queue = append(queue, delivery{messageID: sim.lastMsg, from: i, to: peer, data: updates.(*vivaldi.VivaldiMetadata[float64])})
+			history.sent[peer] = true
+		}
+		history.patience--
+	}
+
+	messages := 0
+	for len(queue) > 0 {
+		msg := queue[0]
+		queue = queue[1:]
+		messages++
+
+		rtt, _ := sim.matrix.Rtt(msg.from, msg.to)
+		err := sim.cores[msg.to].UpdateState(vivaldi.CloneMetadata(msg.data, rtt))
+		if err != nil {
+			return RoundStats{}, fmt.Errorf("error updating node %d, details: %s", msg.to, err)
+		}
+
+		history, present := histories[msg.to][msg.messageID]
+		if !present {
+			history = &messageHistory{patience: sim.config.F, sent: make(map[int]bool)}
+			histories[msg.to][msg.messageID] = history
+		}
+		if history.patience == 0 {
+			continue
+		}
+		history.sent[msg.from] = true
+
+		state, _ := sim.cores[msg.to].GetMyState()
+		forwarded := vivaldi.ForwardedMetadata(msg.data, state.(*vivaldi.VivaldiPeerState[float64]))
+		for _, peer := range sim.selectPeers(msg.to, history.sent) {
+			queue = append(queue, delivery{messageID: msg.messageID, from: msg.to, to: peer, data: forwarded})
+			history.sent[peer] = true
+		}
+		history.patience--
+	}
+
+	stats, err := sim.errorStats()
+	if err != nil {
+		return RoundStats{}, err
+	}
+	stats.Round = sim.round
+	stats.Time = sim.now.Sub(sim.start)
+	stats.Messages = messages
+
+	return stats, nil
+}
+
+// selectPeers picks up to B random peers with a known rtt not already reached
+func (sim *Simulator) selectPeers(node int, excluded map[int]bool) []int {
+	candidates := make([]int, 0, len(sim.cores))
+	for peer := range sim.cores {
+		if peer == node || excluded[peer] {
+			continue
+		}
+		if _, ok := sim.matrix.Rtt(node, peer); ok {
+			candidates = append(candidates, peer)
+		}
+	}
+
+	count := min(sim.config.B, len(candidates))
+	for i := 0; i < count; i++ {
+		j := i + sim.rand.Intn(len(candidates)-i)
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	}
+
+	return candidates[:count]
+}
+
+// errorStats compares the distance between the coordinates of every measured
+// pair with its actual rtt
+func (sim *Simulator) errorStats() (RoundStats, error) {
+	points := make([]*nvs.Point[float64], len(sim.cores))
+	for i, cr := range sim.cores {
+		state, _ := cr.GetMyState()
+		coords := state.(*vivaldi.VivaldiPeerState[float64]).Coords
+
+		var err error
+		points[i], err = nvs.NewPoint(sim.space, append(make([]float64, 0, len(coords)), coords...))
+		if err != nil {
+			return RoundStats{}, fmt.Errorf("error reading coordinates of node %d, details: %s", i, err)
+		}
+	}
+
+	relErrors := make([]float64, 0)
+	for i := range points {
+		for j := i + 1; j < len(points); j++ {
+			rtt, ok := sim.matrix.Rtt(i, j)
+			if !ok {
+				continue
+			}
+			predicted, err := sim.space.Distance(points[i], points[j])
+			if err != nil {
+				return RoundStats{}, fmt.Errorf("error computing distances, details: %s", err)
+			}
+			relErrors = append(relErrors, math.Abs(predicted-rtt)/rtt)
+		}
+	}
+	if len(relErrors) == 0 {
+		return RoundStats{}, errors.New("no measured pair to evaluate")
+	}
+
+	sort.Float64s(relErrors)
+
+	return RoundStats{
+		MedianError: quantile(relErrors, 0.5),
+		P90Error:    quantile(relErrors, 0.9),
+		CDF:         ErrorCDF(relErrors),
+	}, nil
+}
+
+// ErrorCDF returns the cumulative distribution of sorted relative errors at every percentile
+func ErrorCDF(sortedErrors []float64) []CDFPoint {
+	retVal := make([]CDFPoint, 100)
+	for q := 1; q <= 100; q++ {
+		fraction := float64(q) / 100.
+		retVal[q-1] = CDFPoint{RelativeError: quantile(sortedErrors, fraction), Fraction: fraction}
+	}
+	return retVal
+}
+
+func quantile(sortedValues []float64, fraction float64) float64 {
+	idx := int(math.Ceil(fraction*float64(len(sortedValues)))) - 1
+	return sortedValues[max(idx, 0)]
+}
@@ -0,0 +1,77 @@
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func planeMatrix(nodes int, seed int64) string {
+	random := rand.New(rand.NewSource(seed))
+	positions := make([][2]float64, nodes)
+	for i := range positions {
+		positions[i] = [2]float64{random.Float64() * 100., random.Float64() * 100.}
+	}
+
+	builder := strings.Builder{}
+	for i := 0; i < nodes; i++ {
+		for j := 0; j < nodes; j++ {
+			rtt := -1.
+			if i != j {
+				rtt = (math.Hypot(positions[i][0]-positions[j][0], positions[i][1]-positions[j][1]) + 1.) * 1000.
+			}
+			fmt.Fprintf(&builder, "%f ", rtt)
+		}
+		builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
+
+func TestSimulatorConvergesDeterministically(t *testing.T) {
+	matrix, err := ReadMatrix(strings.NewReader(planeMatrix(20, 7)), 0.001)
+	if err != nil {
+		t.Fatalf("Unable to read matrix, details: %s", err)
+	}
+
+	config := Config{Dimension: 2, Ce: 0.25, Cc: 0.25, B: 2, F: 1, Rounds: 40, Seed: 3}
+
+	run := func() *Report {
+		sim, err := NewSimulator(matrix, config)
+		if err != nil {
+			t.Fatalf("Unable to create simulator, details: %s", err)
+		}
+		report, err := sim.Run()
+		if err != nil {
+			t.Fatalf("Simulation failed, details: %s", err)
+		}
+		return report
+	}
+
+	first, second := run(), run()
+
+	if !first.Converged {
+		t.Fatalf("Simulation did not converge, final median error %v", first.Rounds[len(first.Rounds)-1].MedianError)
+	}
+	for i := range first.Rounds {
+		if first.Rounds[i].MedianError != second.Rounds[i].MedianError {
+			t.Fatalf("Runs with the same seed diverged at round %d", i+1)
+		}
+	}
+}
+
+func TestReadTriplets(t *testing.T) {
+	matrix, err := ReadTriplets(strings.NewReader("0 1 10\n1 2 20\n# comment\n2 0 30\n"), 1.)
+	if err != nil {
+		t.Fatalf("Unable to read triplets, details: %s", err)
+	}
+
+	if rtt, ok := matrix.Rtt(1, 0); !ok || rtt != 10. {
+		t.Fatalf("Missing symmetric entry, got %v", rtt)
+	}
+	if _, ok := matrix.Rtt(1, 1); ok {
+		t.Fatalf("Diagonal should not be measured")
+	}
+}
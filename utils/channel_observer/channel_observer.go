@@ -75,7 +75,7 @@ func (chimpl *ChannelObserverSubjectImpl) UnregisterChannel(num int) bool {
 		delete(chimpl.channels.Map, num)
 	}
 
-	return false
+	return ok
 }
 
 //Observer implementation